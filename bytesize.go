@@ -0,0 +1,180 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// byteUnits are the units understood by ParseBytes and used by FormatBytes,
+// largest first.
+var byteUnits = []struct {
+	name string
+	size uint64
+}{
+	{"EiB", 1 << 60},
+	{"EB", 1e18},
+	{"PiB", 1 << 50},
+	{"PB", 1e15},
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"kB", 1e3},
+	{"B", 1},
+}
+
+// ParseBytes parses a size in bytes such as "512MiB", "10GB" or "1.5 KiB".
+// The number may have a fractional part and be followed by an SI unit (kB,
+// MB, GB, TB, PB, EB), an IEC unit (KiB, MiB, GiB, TiB, PiB, EiB) or B.
+// Units are not case sensitive, and a number without a unit is a number of
+// bytes. Fractions of a byte are truncated.
+// An error is returned if the size is negative or does not fit in a uint64.
+func ParseBytes(s string) (uint64, error) {
+	var str = strings.TrimSpace(s)
+	var i = strings.IndexFunc(str, unicode.IsLetter)
+	var num, unit = str, ""
+	if i >= 0 {
+		num, unit = strings.TrimSpace(str[:i]), str[i:]
+	}
+	if num == "" {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	var size uint64 = 1
+	if unit != "" {
+		var found bool
+		for _, u := range byteUnits {
+			if strings.EqualFold(unit, u.name) {
+				size, found = u.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+		}
+	}
+
+	if n, err := strconv.ParseUint(num, 10, 64); err == nil {
+		hi, lo := bits.Mul64(n, size)
+		if hi != 0 {
+			return 0, fmt.Errorf("invalid byte size %q: %w", s, strconv.ErrRange)
+		}
+		return lo, nil
+	}
+
+	// fractional values are computed exactly so that large sizes are not rounded
+	var r, ok = new(big.Rat).SetString(num)
+	if !ok || strings.ContainsAny(num, "/eE") {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	if r.Sign() < 0 {
+		return 0, fmt.Errorf("invalid byte size %q: must not be negative", s)
+	}
+	r.Mul(r, new(big.Rat).SetUint64(size))
+	var n = new(big.Int).Quo(r.Num(), r.Denom())
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("invalid byte size %q: %w", s, strconv.ErrRange)
+	}
+	return n.Uint64(), nil
+}
+
+// FormatBytes formats a size in bytes using the largest unit that represents
+// it exactly with at most one decimal place, e.g. "512MiB", "10GB" or
+// "1.5KiB". The result can be parsed by ParseBytes to get n back.
+func FormatBytes(n uint64) string {
+	for _, u := range byteUnits {
+		if n < u.size || u.size == 1 {
+			continue
+		}
+		var rem = n % u.size
+		hi, lo := bits.Mul64(rem, 10)
+		if bits.Rem64(hi, lo, u.size) != 0 {
+			continue
+		}
+		var frac, _ = bits.Div64(hi, lo, u.size)
+		var str = strconv.FormatUint(n/u.size, 10)
+		if frac != 0 {
+			str += "." + strconv.FormatUint(frac, 10)
+		}
+		return str + u.name
+	}
+	return strconv.FormatUint(n, 10) + "B"
+}
+
+// ByteSize is a size in bytes that is parsed by ParseBytes and formatted by
+// FormatBytes when used with Get, GetOrDefault or TypeOf.
+type ByteSize uint64
+
+// String formats the size with FormatBytes.
+func (b ByteSize) String() string {
+	return FormatBytes(uint64(b))
+}
+
+// MarshalText formats the size with FormatBytes.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText parses the size with ParseBytes.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	n, err := ParseBytes(string(text))
+	if err != nil {
+		return err
+	}
+	*b = ByteSize(n)
+	return nil
+}
+
+// Bytes returns the value associated with the given key as a number of bytes,
+// as parsed by ParseBytes, e.g. "512MiB" or "10GB".
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a number of bytes.
+func (c *Config) Bytes(key string) (val uint64, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	return ParseBytes(str)
+}
+
+// BytesOrDefault returns the value associated with the given key as a number of bytes.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) BytesOrDefault(key string, def uint64) (val uint64, used bool) {
+	var err error
+	val, err = c.Bytes(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out uint64
+		err bool
+	}{
+		{"0", 0, false},
+		{"1234", 1234, false},
+		{"1234B", 1234, false},
+		{"1kB", 1000, false},
+		{"1KB", 1000, false},
+		{"1KiB", 1024, false},
+		{"512MiB", 512 << 20, false},
+		{"512 mib", 512 << 20, false},
+		{"10GB", 10e9, false},
+		{"1.5KiB", 1536, false},
+		{"1.5 GiB", 3 << 29, false},
+		{"0.1kB", 100, false},
+		{"1.0005kB", 1000, false},
+		{"2TiB", 2 << 40, false},
+		{"3PB", 3e15, false},
+		{"15EiB", 15 << 60, false},
+		{"18446744073709551615", 18446744073709551615, false},
+		{"16EiB", 0, true},
+		{"18446744073709551616", 0, true},
+		{"16.5EiB", 0, true},
+		{"-1KiB", 0, true},
+		{"KiB", 0, true},
+		{"", 0, true},
+		{"12XB", 0, true},
+		{"1e3", 0, true},
+		{"1/2KiB", 0, true},
+		{"bravo", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			n, err := ParseBytes(test.in)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect an error: %s", err)
+			}
+			if n != test.out {
+				t.Errorf("expected %d but got %d", test.out, n)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	var tests = []struct {
+		in  uint64
+		out string
+	}{
+		{0, "0B"},
+		{999, "999B"},
+		{1000, "1kB"},
+		{1024, "1KiB"},
+		{1536, "1.5KiB"},
+		{1537, "1537B"},
+		{2500, "2.5kB"},
+		{1024000, "1000KiB"},
+		{512 << 20, "512MiB"},
+		{10e9, "10GB"},
+		{1 << 60, "1EiB"},
+		{18446744073709551615, "18446744073709551615B"},
+	}
+	for _, test := range tests {
+		t.Run(test.out, func(t *testing.T) {
+			var str = FormatBytes(test.in)
+			if str != test.out {
+				t.Errorf("expected %s but got %s", test.out, str)
+			}
+			if n, err := ParseBytes(str); err != nil || n != test.in {
+				t.Errorf("expected %s to parse back to %d but got %d (%v)", str, test.in, n, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Bytes(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	cache = 512MiB
+	upload = lots
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	if n, err := cfg.Bytes("cache"); err != nil || n != 512<<20 {
+		t.Errorf("expected %d but got %d (%v)", 512<<20, n, err)
+	}
+	if _, err := cfg.Bytes("upload"); err == nil {
+		t.Error("expected an error but did not get one")
+	}
+	if _, err := cfg.Bytes("missing"); err != ErrKeyNotFound {
+		t.Error("expected 'ErrKeyNotFound'")
+	}
+	if n, used := cfg.BytesOrDefault("upload", 10e6); n != 10e6 || !used {
+		t.Errorf("expected default %d but got %d", uint64(10e6), n)
+	}
+	if n, used := cfg.BytesOrDefault("cache", 10e6); n != 512<<20 || used {
+		t.Errorf("expected %d but got %d", 512<<20, n)
+	}
+
+	t.Run("ByteSize", func(t *testing.T) {
+		size, err := Get[ByteSize](cfg, "cache")
+		if err != nil || size != 512<<20 {
+			t.Errorf("expected %d but got %d (%v)", 512<<20, size, err)
+		}
+		if size.String() != "512MiB" {
+			t.Errorf("expected '512MiB' but got '%s'", size)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// OnChange registers fn to be called after a successful re-read in which the
+// value of key in the named section changed. Additions and deletions count as
+// changes: old is "" when the key was added and new is "" when it was removed.
+//
+// Change callbacks are run one at a time, after the Subscribe callbacks, in
+// the order they were registered. A callback may call Reload; the callbacks
+// for that reload run after the current ones have returned.
+func (w *Watcher) OnChange(section, key string, fn func(old, new string)) {
+	w.onChange(func(prev, next map[string]*Config) {
+		oldVal, oldOK := lookup(prev, section, key)
+		newVal, newOK := lookup(next, section, key)
+		if oldOK != newOK || oldVal != newVal {
+			fn(oldVal, newVal)
+		}
+	})
+}
+
+func (w *Watcher) onChange(fn func(prev, next map[string]*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.changes = append(w.changes, fn)
+}
+
+func lookup(cfgs map[string]*Config, section, key string) (val string, ok bool) {
+	cfg, ok := cfgs[section]
+	if !ok {
+		return "", false
+	}
+	return cfg.get(key)
+}
+
+// onTypedChange registers fn to be called when the value of key, as returned
+// by get, changed. Missing and unparsable values are reported as the zero value
+// of T, but a key being added, removed or becoming parsable or unparsable is
+// reported even if both values are the zero value.
+func onTypedChange[T any](w *Watcher, section, key string, get func(*Config, string) (T, error), equal func(a, b T) bool, fn func(old, new T)) {
+	var value = func(cfgs map[string]*Config) (val T, ok bool) {
+		if cfg, prs := cfgs[section]; prs {
+			if v, err := get(cfg.quiet(key), key); err == nil {
+				return v, true
+			}
+		}
+		return val, false
+	}
+	w.onChange(func(prev, next map[string]*Config) {
+		var oldVal, oldOK = value(prev)
+		var newVal, newOK = value(next)
+		if oldOK != newOK || !equal(oldVal, newVal) {
+			fn(oldVal, newVal)
+		}
+	})
+}
+
+func eq[T comparable](a, b T) bool {
+	return a == b
+}
+
+// OnBoolChange is like OnChange but compares and reports the values as bools.
+// Missing and unparsable values are reported as false, but additions and
+// deletions are reported even when the other value is also false.
+func (w *Watcher) OnBoolChange(section, key string, fn func(old, new bool)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnFloat32Change is like OnChange but compares and reports the values as float32s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnFloat32Change(section, key string, fn func(old, new float32)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnFloat64Change is like OnChange but compares and reports the values as float64s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnFloat64Change(section, key string, fn func(old, new float64)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnIntChange is like OnChange but compares and reports the values as ints.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnIntChange(section, key string, fn func(old, new int)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnInt8Change is like OnChange but compares and reports the values as int8s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnInt8Change(section, key string, fn func(old, new int8)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnInt16Change is like OnChange but compares and reports the values as int16s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnInt16Change(section, key string, fn func(old, new int16)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnInt32Change is like OnChange but compares and reports the values as int32s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnInt32Change(section, key string, fn func(old, new int32)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnInt64Change is like OnChange but compares and reports the values as int64s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnInt64Change(section, key string, fn func(old, new int64)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnUintChange is like OnChange but compares and reports the values as uints.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnUintChange(section, key string, fn func(old, new uint)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnUint8Change is like OnChange but compares and reports the values as uint8s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnUint8Change(section, key string, fn func(old, new uint8)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnUint16Change is like OnChange but compares and reports the values as uint16s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnUint16Change(section, key string, fn func(old, new uint16)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnUint32Change is like OnChange but compares and reports the values as uint32s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnUint32Change(section, key string, fn func(old, new uint32)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnUint64Change is like OnChange but compares and reports the values as uint64s.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnUint64Change(section, key string, fn func(old, new uint64)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnDurationChange is like OnChange but compares and reports the values as
+// time.Durations, so "60s" changing to "1m" is not reported.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnDurationChange(section, key string, fn func(old, new time.Duration)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnURLChange is like OnChange but compares and reports the values as *url.URLs.
+// Missing and unparsable values are reported as nil, but additions and
+// deletions are reported even when the other value is also nil.
+func (w *Watcher) OnURLChange(section, key string, fn func(old, new *url.URL)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnFilePathChange is like OnChange but compares and reports the values as
+// cleaned file paths.
+// Missing values are reported as "", but additions and deletions are
+// reported even when the other value is also "".
+func (w *Watcher) OnFilePathChange(section, key string, fn func(old, new string)) {
+	onTypedChange(w, section, key, (*Config).FilePath, eq[string], fn)
+}
+
+// OnIPChange is like OnChange but compares and reports the values as net.IPs.
+// Missing and unparsable values are reported as nil, but additions and
+// deletions are reported even when the other value is also nil.
+func (w *Watcher) OnIPChange(section, key string, fn func(old, new net.IP)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnBytesChange is like OnChange but compares and reports the values as
+// numbers of bytes, so "1KiB" changing to "1024" is not reported.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnBytesChange(section, key string, fn func(old, new uint64)) {
+	onTypedChange(w, section, key, (*Config).Bytes, eq[uint64], fn)
+}
+
+// OnFileModeChange is like OnChange but compares and reports the values as
+// os.FileModes, so "0640" changing to "rw-r-----" is not reported.
+// Missing and unparsable values are reported as 0, but additions and
+// deletions are reported even when the other value is also 0.
+func (w *Watcher) OnFileModeChange(section, key string, fn func(old, new os.FileMode)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnTimeOfDayChange is like OnChange but compares and reports the values as
+// TimeOfDays, so "9:05" changing to "09:05:00" is not reported.
+// Missing and unparsable values are reported as the zero TimeOfDay, but additions and
+// deletions are reported even when the other value is also the zero TimeOfDay.
+func (w *Watcher) OnTimeOfDayChange(section, key string, fn func(old, new TimeOfDay)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnWindowChange is like OnChange but compares and reports the values as Windows.
+// Missing and unparsable values are reported as the zero Window, but additions and
+// deletions are reported even when the other value is also the zero Window.
+func (w *Watcher) OnWindowChange(section, key string, fn func(old, new Window)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnScheduleChange is like OnChange but compares and reports the values as
+// Schedules, so "mon-fri 9:00-17:00" changing to "Mon-Fri 09:00-17:00" is not reported.
+// Missing and unparsable values are reported as nil, but additions and
+// deletions are reported even when the other value is also nil.
+func (w *Watcher) OnScheduleChange(section, key string, fn func(old, new Schedule)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnCronChange is like OnChange but compares and reports the values as
+// Crons, so "@daily" changing to "0 0 * * *" is not reported.
+// Missing and unparsable values are reported as the zero Cron, but additions and
+// deletions are reported even when the other value is also the zero Cron.
+func (w *Watcher) OnCronChange(section, key string, fn func(old, new Cron)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+// OnTimeChange is like OnChange but compares and reports the values as
+// time.Times parsed as Config.Time would, so a change that names the same
+// instant in another time zone is not reported.
+// Missing and unparsable values are reported as the zero time.Time, but
+// additions and deletions are reported even when the other value is also the zero time.Time.
+func (w *Watcher) OnTimeChange(section, key string, fn func(old, new time.Time), layout ...string) {
+	var get = func(c *Config, key string) (time.Time, error) { return c.Time(key, layout...) }
+	onTypedChange(w, section, key, get, time.Time.Equal, fn)
+}
+
+// OnDateChange is like OnChange but compares and reports the values as dates.
+// Missing and unparsable values are reported as the zero time.Time, but
+// additions and deletions are reported even when the other value is also the zero time.Time.
+func (w *Watcher) OnDateChange(section, key string, fn func(old, new time.Time)) {
+	onTypedChange(w, section, key, (*Config).Date, time.Time.Equal, fn)
+}
+
+// OnLocationChange is like OnChange but compares and reports the values as
+// *time.Locations. Missing and unparsable values are reported as nil, but
+// additions and deletions are reported even when the other value is also nil.
+func (w *Watcher) OnLocationChange(section, key string, fn func(old, new *time.Location)) {
+	OnChangeOf(w, section, key, fn)
+}
+
+func equalURLs(a, b *url.URL) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+func equalLocations(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatcher_OnChange(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, `
+log:
+	level = info
+	format = json
+limits:
+	rate = 10
+	burst = 60s
+`)
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var calls []string
+	var record = func(name string) func(old, new string) {
+		return func(old, new string) {
+			calls = append(calls, name+":"+old+"->"+new)
+		}
+	}
+	w.OnChange("log", "level", record("level"))
+	w.OnChange("log", "format", record("format"))
+	w.OnChange("log", "file", record("file"))
+	w.OnChange("limits", "rate", record("rate"))
+	w.OnDurationChange("limits", "burst", func(old, new time.Duration) {
+		calls = append(calls, "burst:"+old.String()+"->"+new.String())
+	})
+
+	writeFile(t, path, `
+log:
+	level = debug
+	file = /var/log/app.log
+limits:
+	rate = 10
+	burst = 1m
+`)
+	w.reload()
+
+	var exp = []string{
+		"level:info->debug",
+		"format:json->",
+		"file:->/var/log/app.log",
+	}
+	if !reflect.DeepEqual(calls, exp) {
+		t.Errorf("expected %#v but got %#v", exp, calls)
+	}
+
+	calls = nil
+	writeFile(t, path, `
+limits:
+	burst = 2m
+`)
+	w.reload()
+	exp = []string{
+		"level:debug->",
+		"file:/var/log/app.log->",
+		"rate:10->",
+		"burst:1m0s->2m0s",
+	}
+	if !reflect.DeepEqual(calls, exp) {
+		t.Errorf("expected %#v but got %#v", exp, calls)
+	}
+}
+
+func TestWatcher_OnIntChange(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "rate = 10\n")
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got [][2]int
+	w.OnIntChange("", "rate", func(old, new int) {
+		got = append(got, [2]int{old, new})
+	})
+
+	writeFile(t, path, "rate = 10\n# unchanged\n")
+	w.reload()
+	writeFile(t, path, "rate = 20\n")
+	w.reload()
+	writeFile(t, path, "rate = kilo\n")
+	w.reload()
+
+	var exp = [][2]int{{10, 20}, {20, 0}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v but got %v", exp, got)
+	}
+}
+
+func TestWatcher_OnIntChange_Zero(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "rate = 0\n")
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got [][2]int
+	w.OnIntChange("", "rate", func(old, new int) {
+		got = append(got, [2]int{old, new})
+	})
+
+	// removed, re-added and made unparsable while the value is zero
+	writeFile(t, path, "\n")
+	w.reload()
+	writeFile(t, path, "rate = 0\n")
+	w.reload()
+	writeFile(t, path, "rate = kilo\n")
+	w.reload()
+	writeFile(t, path, "rate = mega\n")
+	w.reload()
+
+	var exp = [][2]int{{0, 0}, {0, 0}, {0, 0}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v but got %v", exp, got)
+	}
+}
+
+func TestOnChangeOf(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "region = us\nat = 02:30\n")
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var regions []string
+	OnChangeOf(w, "", "region", func(old, new region) {
+		regions = append(regions, old.code+"->"+new.code)
+	})
+	var times []string
+	OnChangeOf(w, "", "at", func(old, new TimeOfDay) {
+		times = append(times, old.String()+"->"+new.String())
+	})
+
+	// "US" parses to the same region and "2:30" to the same time of day
+	writeFile(t, path, "region = US\nat = 2:30\n")
+	w.reload()
+	writeFile(t, path, "region = eu\nat = 03:00\n")
+	w.reload()
+
+	if exp := []string{"US->EU"}; !reflect.DeepEqual(regions, exp) {
+		t.Errorf("expected %v but got %v", exp, regions)
+	}
+	if exp := []string{"02:30->03:00"}; !reflect.DeepEqual(times, exp) {
+		t.Errorf("expected %v but got %v", exp, times)
+	}
+}
@@ -0,0 +1,333 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"time"
+)
+
+// ErrMergeConflict returned when merging with MergeError and both Configs
+// have different values for the same key
+var ErrMergeConflict = errors.New("conflicting values for key")
+
+// MergeStrategy decides what happens when both Configs being merged have a key.
+type MergeStrategy int
+
+const (
+	// MergeReplace replaces existing values with those from the other Config.
+	MergeReplace MergeStrategy = iota
+	// MergeKeep keeps existing values and only adds keys that are missing.
+	MergeKeep
+	// MergeError fails without changing anything if the Configs have
+	// different values for the same key.
+	MergeError
+)
+
+// Clone returns a copy of the configuration's name, key/value pairs and
+// settings for parsing values, such as SetDecimalIntegers.
+// Variables bound to keys of c, tracking and callbacks are not copied.
+func (c *Config) Clone() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var clone = &Config{
+		name:     c.name,
+		decimal:  c.decimal,
+		baseDir:  c.baseDir,
+		pathOpts: c.pathOpts,
+		m:        make(map[string]string, len(c.m)),
+		keys:     append([]string(nil), c.keys...),
+	}
+	for key, val := range c.m {
+		clone.m[key] = val
+	}
+	if c.dirs != nil {
+		clone.dirs = make(map[string]string, len(c.dirs))
+		for key, dir := range c.dirs {
+			clone.dirs[key] = dir
+		}
+	}
+	return clone
+}
+
+// Merge adds the key/value pairs of other to the configuration, resolving
+// keys present in both according to strategy.
+// If strategy is MergeError, an error wrapping ErrMergeConflict is returned
+// for the first conflicting key and the configuration is left unchanged.
+func (c *Config) Merge(other *Config, strategy MergeStrategy) error {
+	var src = other.Clone()
+	if strategy == MergeError {
+		for _, key := range src.keys {
+			if val, ok := c.get(key); ok && val != src.m[key] {
+				return fmt.Errorf("%w %q in %q", ErrMergeConflict, key, c.Name())
+			}
+		}
+	}
+	for _, key := range src.keys {
+		if strategy == MergeKeep && c.Has(key) {
+			continue
+		}
+		c.Set(key, src.m[key])
+		if dir, ok := src.dirs[key]; ok {
+			c.mu.Lock()
+			if c.dirs == nil {
+				c.dirs = make(map[string]string)
+			}
+			c.dirs[key] = dir
+			c.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// Equal reports whether both configurations have exactly the same key/value
+// pairs. Names and the order of keys are ignored.
+func (c *Config) Equal(other *Config) bool {
+	var a, b = c.Clone(), other.Clone()
+	return reflect.DeepEqual(a.m, b.m)
+}
+
+// Clone returns a copy of every Config, as Config.Clone would.
+func (cs *Configs) Clone() *Configs {
+	cs.lazyInit()
+	var clone = &Configs{
+		m:     make(map[string]*Config, len(cs.m)),
+		names: append([]string(nil), cs.names...),
+	}
+	for name, cfg := range cs.m {
+		clone.m[name] = cfg.Clone()
+	}
+	return clone
+}
+
+// Merge merges each Config in other into the Config with the same name,
+// adding Configs that are missing, as Config.Merge would.
+// If strategy is MergeError and there is a conflict, nothing is changed.
+func (cs *Configs) Merge(other *Configs, strategy MergeStrategy) error {
+	cs.lazyInit()
+	other.lazyInit()
+	if strategy == MergeError {
+		for _, name := range other.names {
+			if cfg, prs := cs.m[name]; prs {
+				if err := cfg.Clone().Merge(other.m[name], MergeError); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, name := range other.names {
+		if err := cs.Section(name).Merge(other.m[name], strategy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Equal reports whether both have the same named Configs with equal
+// key/value pairs.
+func (cs *Configs) Equal(other *Configs) bool {
+	cs.lazyInit()
+	other.lazyInit()
+	if len(cs.m) != len(other.m) {
+		return false
+	}
+	for name, cfg := range cs.m {
+		if o, prs := other.m[name]; !prs || !cfg.Equal(o) {
+			return false
+		}
+	}
+	return true
+}
+
+// Type is a kind of value understood by the package, used to compare and
+// validate values by meaning rather than by their text.
+type Type struct {
+	name string
+	get  func(c *Config, key string) (interface{}, error)
+}
+
+// String returns the name of the type.
+func (t Type) String() string {
+	if t.name == "" {
+		return "string"
+	}
+	return t.name
+}
+
+// value returns the value of key interpreted as type t.
+// Getting the value does not count as a read of c.
+func (t Type) value(c *Config, key string) (interface{}, error) {
+	if t.get == nil {
+		return c.quiet(key).String(key)
+	}
+	return t.get(c.quiet(key), key)
+}
+
+// The Types of values the package can parse.
+var (
+	TypeString    = newType[string]("string")
+	TypeBool      = newType[bool]("bool")
+	TypeFloat32   = newType[float32]("float32")
+	TypeFloat64   = newType[float64]("float64")
+	TypeInt       = newType[int]("int")
+	TypeInt8      = newType[int8]("int8")
+	TypeInt16     = newType[int16]("int16")
+	TypeInt32     = newType[int32]("int32")
+	TypeInt64     = newType[int64]("int64")
+	TypeUint      = newType[uint]("uint")
+	TypeUint8     = newType[uint8]("uint8")
+	TypeUint16    = newType[uint16]("uint16")
+	TypeUint32    = newType[uint32]("uint32")
+	TypeUint64    = newType[uint64]("uint64")
+	TypeDuration  = newType[time.Duration]("duration")
+	TypeURL       = newType[*url.URL]("URL")
+	TypeFilePath  = Type{"file path", func(c *Config, key string) (interface{}, error) { return c.FilePath(key) }}
+	TypeTimeOfDay = newType[TimeOfDay]("time of day")
+	TypeIP        = newType[net.IP]("IP")
+	TypeBytes     = Type{"byte size", func(c *Config, key string) (interface{}, error) { return c.Bytes(key) }}
+	TypeFileMode  = newType[os.FileMode]("file mode")
+	TypeTime      = newType[time.Time]("time")
+	TypeDate      = Type{"date", func(c *Config, key string) (interface{}, error) { return c.Date(key) }}
+	TypeWindow    = newType[Window]("window")
+	TypeSchedule  = newType[Schedule]("schedule")
+	TypeCron      = newType[Cron]("cron expression")
+	TypeLocation  = newType[*time.Location]("time zone")
+)
+
+// Types maps keys to the Type their values should be compared as.
+type Types map[string]Type
+
+// Diff describes how the key/value pairs of a configuration differ from
+// those of another.
+type Diff struct {
+	// Section is the name of the configuration.
+	Section string
+	// Added are the keys only present in the other configuration.
+	Added []string
+	// Removed are the keys no longer present in the other configuration.
+	Removed []string
+	// Changed are the keys present in both with different values.
+	Changed []string
+}
+
+// Empty reports whether the Diff found no differences.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff reports the keys added, removed and changed going from c to other.
+// Values of keys listed in types are parsed as that Type and compared by
+// meaning, so "60s" and "1m" are equal when compared as TypeDuration. If
+// either value fails to parse, the text is compared instead. Other values
+// are compared as text. types may be nil.
+func (c *Config) Diff(other *Config, types Types) Diff {
+	var a, b = c.Clone(), other.Clone()
+	var d = Diff{Section: a.name}
+	for _, key := range a.keys {
+		if _, prs := b.m[key]; !prs {
+			d.Removed = append(d.Removed, key)
+		} else if !equalValues(a, b, key, types[key]) {
+			d.Changed = append(d.Changed, key)
+		}
+	}
+	for _, key := range b.keys {
+		if _, prs := a.m[key]; !prs {
+			d.Added = append(d.Added, key)
+		}
+	}
+	return d
+}
+
+func equalValues(a, b *Config, key string, t Type) bool {
+	if a.m[key] == b.m[key] {
+		return true
+	}
+	if t.get == nil {
+		return false
+	}
+	av, err := t.value(a, key)
+	if err != nil {
+		return false
+	}
+	bv, err := t.value(b, key)
+	if err != nil {
+		return false
+	}
+	switch av := av.(type) {
+	case time.Time:
+		bv, ok := bv.(time.Time)
+		return ok && av.Equal(bv)
+	case TimeOfDay:
+		bv, ok := bv.(TimeOfDay)
+		return ok && av.Equal(bv)
+	case Window:
+		bv, ok := bv.(Window)
+		return ok && av.Equal(bv)
+	case Schedule:
+		bv, ok := bv.(Schedule)
+		return ok && av.Equal(bv)
+	case Cron:
+		bv, ok := bv.(Cron)
+		return ok && av.Equal(bv)
+	case *time.Location:
+		bv, ok := bv.(*time.Location)
+		return ok && equalLocations(av, bv)
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// Diff reports the differences going from cs to other for each named Config,
+// as Config.Diff would, omitting Configs without differences. Configs missing
+// from one side are treated as empty. types holds the Types for each named
+// Config and may be nil.
+func (cs *Configs) Diff(other *Configs, types map[string]Types) []Diff {
+	cs.lazyInit()
+	other.lazyInit()
+	var names = append([]string(nil), cs.names...)
+	for _, name := range other.names {
+		if _, prs := cs.m[name]; !prs {
+			names = append(names, name)
+		}
+	}
+	var diffs []Diff
+	for _, name := range names {
+		var a, b = cs.m[name], other.m[name]
+		if a == nil {
+			a = New()
+		}
+		if b == nil {
+			b = New()
+		}
+		var d = a.Diff(b, types[name])
+		d.Section = name
+		if !d.Empty() {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
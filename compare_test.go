@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Clone(t *testing.T) {
+	var cfg = FromMap(map[string]string{"a": "1"})
+	var port int
+	IntVar(&port, cfg, "port", 80)
+
+	var clone = cfg.Clone()
+	clone.Set("a", "2")
+	clone.Set("port", "8080")
+	if val, _ := cfg.String("a"); val != "1" {
+		t.Errorf("expected original to be unchanged but got '%s'", val)
+	}
+	if port != 80 {
+		t.Errorf("expected bound variable to be unchanged but got %d", port)
+	}
+	if !cfg.Equal(FromMap(map[string]string{"a": "1"})) {
+		t.Error("expected configs to be equal")
+	}
+	if cfg.Equal(clone) {
+		t.Error("did not expect configs to be equal")
+	}
+}
+
+func TestConfig_Merge(t *testing.T) {
+	var tests = []struct {
+		strategy MergeStrategy
+		exp      map[string]string
+		err      error
+	}{
+		{MergeReplace, map[string]string{"a": "1", "b": "3", "c": "4"}, nil},
+		{MergeKeep, map[string]string{"a": "1", "b": "2", "c": "4"}, nil},
+		{MergeError, map[string]string{"a": "1", "b": "2"}, ErrMergeConflict},
+	}
+
+	for _, test := range tests {
+		var cfg = FromMap(map[string]string{"a": "1", "b": "2"})
+		var other = FromMap(map[string]string{"b": "3", "c": "4"})
+		var err = cfg.Merge(other, test.strategy)
+		if !errors.Is(err, test.err) {
+			t.Errorf("strategy %d: expected error %v but got %v", test.strategy, test.err, err)
+		}
+		if !cfg.Equal(FromMap(test.exp)) {
+			t.Errorf("strategy %d: expected %v but got %v", test.strategy, test.exp, cfg.m)
+		}
+	}
+
+	var cfg = FromMap(map[string]string{"a": "1"})
+	if err := cfg.Merge(FromMap(map[string]string{"a": "1", "b": "2"}), MergeError); err != nil {
+		t.Errorf("did not expect an error for equal values: %s", err)
+	}
+}
+
+func TestConfigs_Merge(t *testing.T) {
+	base, err := ReadConfigs(strings.NewReader(`
+db:
+	host = localhost
+	port = 5432
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	override, err := ReadConfigs(strings.NewReader(`
+db:
+	port = 6543
+log:
+	level = debug
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var clone = base.Clone()
+	if err := clone.Merge(override, MergeError); !errors.Is(err, ErrMergeConflict) {
+		t.Errorf("expected ErrMergeConflict but got %v", err)
+	}
+	if !clone.Equal(base) {
+		t.Error("expected a failed merge to change nothing")
+	}
+
+	if err := clone.Merge(override, MergeReplace); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if port, _ := clone.Config("db").Int("port"); port != 6543 {
+		t.Errorf("expected 6543 but got %d", port)
+	}
+	if level, _ := clone.Config("log").String("level"); level != "debug" {
+		t.Errorf("expected 'debug' but got '%s'", level)
+	}
+	if port, _ := base.Config("db").Int("port"); port != 5432 {
+		t.Errorf("expected the original to be unchanged but got %d", port)
+	}
+}
+
+func TestConfigs_Diff(t *testing.T) {
+	running, err := ReadConfigs(strings.NewReader(`
+timeout = 60s
+retries = 3
+mask = 16
+db:
+	host = localhost
+	port = 5432
+cache:
+	size = 10
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	candidate, err := ReadConfigs(strings.NewReader(`
+timeout = 1m
+retries = 03
+mask = 0x10
+db:
+	host = db.internal
+	user = admin
+log:
+	level = info
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var exp = []Diff{
+			{Section: "", Changed: []string{"timeout", "retries", "mask"}},
+			{Section: "db", Added: []string{"user"}, Removed: []string{"port"}, Changed: []string{"host"}},
+			{Section: "cache", Removed: []string{"size"}},
+			{Section: "log", Added: []string{"level"}},
+		}
+		if got := running.Diff(candidate, nil); !reflect.DeepEqual(got, exp) {
+			t.Errorf("expected %+v but got %+v", exp, got)
+		}
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		var types = map[string]Types{
+			"": {"timeout": TypeDuration, "retries": TypeInt, "mask": TypeUint8},
+		}
+		var got = running.Diff(candidate, types)
+		if len(got) != 3 || got[0].Section != "db" {
+			t.Errorf("expected no differences in the default config but got %+v", got)
+		}
+	})
+}
@@ -24,16 +24,16 @@
 package config
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net"
 	"net/url"
-	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
@@ -44,28 +44,254 @@ var ErrKeyNotFound = errors.New("key not found")
 // ErrParseValue returned when the value could not be parsed into the given type
 var ErrParseValue = errors.New("failed to parse value into given type")
 
-// Config is a set of key/value pairs
+// Config is a set of key/value pairs.
+// The zero value is an empty Config ready to use.
+// A Config is safe for concurrent use by multiple goroutines, but variables
+// bound to its keys with functions such as IntVar are written without
+// synchronization.
 type Config struct {
-	m map[string]string
+	mu    sync.RWMutex
+	name  string
+	m     map[string]string
+	keys  []string
+	binds map[string][]func()
+
+	decimal   bool
+	dirs      map[string]string
+	baseDir   string
+	pathOpts  PathOptions
+	tracker   *tracker
+	onDefault []func(key, raw string, err error)
+}
+
+// New returns an empty Config.
+func New() *Config {
+	return &Config{
+		m: make(map[string]string),
+	}
+}
+
+// FromMap returns a Config holding a copy of the key/value pairs in m.
+// Since maps are unordered, Keys reports the keys in sorted order.
+func FromMap(m map[string]string) *Config {
+	var c = New()
+	var keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.set(key, m[key])
+	}
+	return c
+}
+
+// Name returns the name of the configuration, "" for the default configuration.
+func (c *Config) Name() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.name
 }
 
 // Set adds a key/value pair to the configuration.
-// If the key already exists, the value will be replaced
+// If the key already exists, the value will be replaced.
+// Any variables bound to the key are updated with the new value.
 func (c *Config) Set(key, val string) {
+	c.mu.Lock()
+	c.set(key, val)
+	var binds = c.binds[key]
+	c.mu.Unlock()
+	for _, fn := range binds {
+		fn()
+	}
+}
+
+// set adds a key/value pair, remembering the order in which keys were first added.
+// The caller must hold the lock or have the only reference to c.
+func (c *Config) set(key, val string) {
+	if c.m == nil {
+		c.m = make(map[string]string)
+	}
+	if _, prs := c.m[key]; !prs {
+		c.keys = append(c.keys, key)
+	}
 	c.m[key] = val
+	delete(c.dirs, key)
+}
+
+// Delete removes the key and its value from the configuration.
+// Any variables bound to the key are updated as if the key was missing.
+func (c *Config) Delete(key string) {
+	c.mu.Lock()
+	if _, prs := c.m[key]; prs {
+		delete(c.m, key)
+		delete(c.dirs, key)
+		for i, k := range c.keys {
+			if k == key {
+				c.keys = append(c.keys[:i:i], c.keys[i+1:]...)
+				break
+			}
+		}
+	}
+	var binds = c.binds[key]
+	c.mu.Unlock()
+	for _, fn := range binds {
+		fn()
+	}
+}
+
+// Has reports whether the key exists in the configuration.
+func (c *Config) Has(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, prs := c.m[key]
+	return prs
+}
+
+// Len returns the number of keys in the configuration.
+func (c *Config) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.m)
+}
+
+// Keys returns the keys in the configuration in the order they were first
+// read or Set.
+func (c *Config) Keys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.keys...)
+}
+
+// All returns an iterator over the key/value pairs in the configuration, in
+// the same order as Keys. The iterator works on a copy taken when it starts,
+// so the configuration may be modified while iterating.
+//
+//	cfg.All()(func(key, val string) bool {
+//		fmt.Println(key, "=", val)
+//		return true
+//	})
+func (c *Config) All() func(yield func(key, val string) bool) {
+	return func(yield func(key, val string) bool) {
+		c.mu.RLock()
+		var keys = append([]string(nil), c.keys...)
+		var vals = make([]string, len(keys))
+		for i, key := range keys {
+			vals[i] = c.m[key]
+		}
+		c.mu.RUnlock()
+		for i := range keys {
+			if !yield(keys[i], vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// SetDecimalIntegers controls whether integer values must be written in
+// decimal. By default, integer getters accept Go integer literals, so "0x1F",
+// "0o755", "0b101" and "1_000_000" are all valid. Unlike in Go, a leading 0
+// without an x, o or b after it does not mean octal, so "0640" is 640.
+// Turning decimal integers on restores the original behavior of accepting
+// only decimal digits.
+func (c *Config) SetDecimalIntegers(on bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decimal = on
+}
+
+func (c *Config) intBase() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.decimal {
+		return 10
+	}
+	return 0
+}
+
+// integerLiteral prepares str to be parsed with the given base. For base 0,
+// the leading zeros of a decimal number, e.g. 0755 or 0_17, are removed so the
+// value stays decimal. Anything else, including a prefix after leading zeros
+// as in 00x10, is left for the parser to accept or reject.
+func integerLiteral(str string, base int) string {
+	if base != 0 {
+		return str
+	}
+	var sign = ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+	if len(str) < 2 || str[0] != '0' || !isDigits(strings.ReplaceAll(str, "_", "")) ||
+		strings.Contains(str, "__") || strings.HasSuffix(str, "_") {
+		return sign + str
+	}
+	if str = strings.TrimLeft(str, "0_"); str == "" {
+		str = "0"
+	}
+	return sign + str
+}
+
+func (c *Config) parseInt(str string, bitSize int) (int64, error) {
+	var base = c.intBase()
+	val, err := strconv.ParseInt(integerLiteral(str, base), base, bitSize)
+	return val, numError(err, str)
+}
+
+func (c *Config) parseUint(str string, bitSize int) (uint64, error) {
+	var base = c.intBase()
+	val, err := strconv.ParseUint(integerLiteral(str, base), base, bitSize)
+	return val, numError(err, str)
+}
+
+// numError makes a *strconv.NumError report the value as it was written.
+func numError(err error, str string) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		ne.Num = str
+	}
+	return err
 }
 
 // String returns the value associated with the given key as a string.
 // If the key does not exist, ErrKeyNotFound is returned.
 func (c *Config) String(key string) (val string, err error) {
 	var ok bool
-	val, ok = c.m[key]
+	val, ok = c.get(key)
+	c.read(key)
 	if !ok {
 		return "", ErrKeyNotFound
 	}
 	return
 }
 
+// get returns the value associated with the given key without it counting
+// as a read.
+func (c *Config) get(key string) (val string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	val, ok = c.m[key]
+	return
+}
+
+// quiet returns a Config holding only the given key, if it exists, so that
+// the package can parse values without it counting as a read of c.
+func (c *Config) quiet(key string) *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var q = &Config{
+		name:     c.name,
+		decimal:  c.decimal,
+		baseDir:  c.baseDir,
+		pathOpts: c.pathOpts,
+	}
+	if val, ok := c.m[key]; ok {
+		q.set(key, val)
+		if dir, ok := c.dirs[key]; ok {
+			q.dirs = map[string]string{key: dir}
+		}
+	}
+	return q
+}
+
 // StringOrDefault returns the value associated with the given key as a string.
 // If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
 // "used" will be true if the default value was used.
@@ -73,6 +299,7 @@ func (c *Config) StringOrDefault(key string, def string) (val string, used bool)
 	var err error
 	val, err = c.String(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -96,6 +323,7 @@ func (c *Config) BoolOrDefault(key string, def bool) (val bool, used bool) {
 	var err error
 	val, err = c.Bool(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -127,6 +355,7 @@ func (c *Config) Float32OrDefault(key string, def float32) (val float32, used bo
 	var err error
 	val, err = c.Float32(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -150,6 +379,7 @@ func (c *Config) Float64OrDefault(key string, def float64) (val float64, used bo
 	var err error
 	val, err = c.Float64(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -158,12 +388,15 @@ func (c *Config) Float64OrDefault(key string, def float64) (val float64, used bo
 // Int returns the value associated with the given key as an int.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into an int.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Int(key string) (val int, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	i64, err := strconv.ParseInt(str, 10, 0)
+	i64, err := c.parseInt(str, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -177,6 +410,69 @@ func (c *Config) IntOrDefault(key string, def int) (val int, used bool) {
 	var err error
 	val, err = c.Int(key)
 	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Int8 returns the value associated with the given key as an int8.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into an int8.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
+func (c *Config) Int8(key string) (val int8, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	i64, err := c.parseInt(str, 8)
+	if err != nil {
+		return 0, err
+	}
+	return int8(i64), nil
+}
+
+// Int8OrDefault returns the value associated with the given key as an int8.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) Int8OrDefault(key string, def int8) (val int8, used bool) {
+	var err error
+	val, err = c.Int8(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Int16 returns the value associated with the given key as an int16.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into an int16.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
+func (c *Config) Int16(key string) (val int16, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	i64, err := c.parseInt(str, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int16(i64), nil
+}
+
+// Int16OrDefault returns the value associated with the given key as an int16.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) Int16OrDefault(key string, def int16) (val int16, used bool) {
+	var err error
+	val, err = c.Int16(key)
+	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -185,12 +481,15 @@ func (c *Config) IntOrDefault(key string, def int) (val int, used bool) {
 // Int32 returns the value associated with the given key as an int32.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into an int32.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Int32(key string) (val int32, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	i64, err := strconv.ParseInt(str, 10, 32)
+	i64, err := c.parseInt(str, 32)
 	if err != nil {
 		return 0, err
 	}
@@ -204,6 +503,7 @@ func (c *Config) Int32OrDefault(key string, def int32) (val int32, used bool) {
 	var err error
 	val, err = c.Int32(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -212,12 +512,15 @@ func (c *Config) Int32OrDefault(key string, def int32) (val int32, used bool) {
 // Int64 returns the value associated with the given key as an int64.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into an int64.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Int64(key string) (val int64, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	return strconv.ParseInt(str, 10, 64)
+	return c.parseInt(str, 64)
 }
 
 // Int64OrDefault returns the value associated with the given key as an int64.
@@ -227,6 +530,7 @@ func (c *Config) Int64OrDefault(key string, def int64) (val int64, used bool) {
 	var err error
 	val, err = c.Int64(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -235,12 +539,15 @@ func (c *Config) Int64OrDefault(key string, def int64) (val int64, used bool) {
 // Uint returns the value associated with the given key as a uint.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into a uint.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Uint(key string) (val uint, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	u64, err := strconv.ParseUint(str, 10, 0)
+	u64, err := c.parseUint(str, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -254,6 +561,69 @@ func (c *Config) UintOrDefault(key string, def uint) (val uint, used bool) {
 	var err error
 	val, err = c.Uint(key)
 	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Uint8 returns the value associated with the given key as a uint8.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a uint8.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
+func (c *Config) Uint8(key string) (val uint8, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	u64, err := c.parseUint(str, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(u64), nil
+}
+
+// Uint8OrDefault returns the value associated with the given key as a uint8.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) Uint8OrDefault(key string, def uint8) (val uint8, used bool) {
+	var err error
+	val, err = c.Uint8(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Uint16 returns the value associated with the given key as a uint16.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a uint16.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
+func (c *Config) Uint16(key string) (val uint16, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	u64, err := c.parseUint(str, 16)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(u64), nil
+}
+
+// Uint16OrDefault returns the value associated with the given key as a uint16.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) Uint16OrDefault(key string, def uint16) (val uint16, used bool) {
+	var err error
+	val, err = c.Uint16(key)
+	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -262,12 +632,15 @@ func (c *Config) UintOrDefault(key string, def uint) (val uint, used bool) {
 // Uint32 returns the value associated with the given key as a uint32.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into a uint32.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Uint32(key string) (val uint32, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	u64, err := strconv.ParseUint(str, 10, 32)
+	u64, err := c.parseUint(str, 32)
 	if err != nil {
 		return 0, err
 	}
@@ -281,6 +654,7 @@ func (c *Config) Uint32OrDefault(key string, def uint32) (val uint32, used bool)
 	var err error
 	val, err = c.Uint32(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -289,12 +663,15 @@ func (c *Config) Uint32OrDefault(key string, def uint32) (val uint32, used bool)
 // Uint64 returns the value associated with the given key as a uint64.
 // If the key does not exist, ErrKeyNotFound is returned.
 // An error is returned if the value cannot be parsed into a uint64.
+// The value may be written as a Go integer literal, such as 0x1F, 0o755 or 1_000_000,
+// unless decimal integers were required with SetDecimalIntegers. A leading 0
+// alone, as in 0640, does not mean octal.
 func (c *Config) Uint64(key string) (val uint64, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return 0, err
 	}
-	return strconv.ParseUint(str, 10, 64)
+	return c.parseUint(str, 64)
 }
 
 // Uint64OrDefault returns the value associated with the given key as a uint64.
@@ -304,6 +681,7 @@ func (c *Config) Uint64OrDefault(key string, def uint64) (val uint64, used bool)
 	var err error
 	val, err = c.Uint64(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -327,6 +705,7 @@ func (c *Config) DurationOrDefault(key string, def time.Duration) (val time.Dura
 	var err error
 	val, err = c.Duration(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -350,6 +729,7 @@ func (c *Config) URLOrDefault(key string, def *url.URL) (val *url.URL, used bool
 	var err error
 	val, err = c.URL(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -357,13 +737,16 @@ func (c *Config) URLOrDefault(key string, def *url.URL) (val *url.URL, used bool
 
 // FilePath returns the value associated with the given key as a string that
 // has been interpreted as a file path and cleaned.
+// Depending on the options set with SetPathOptions, environment variables and
+// a leading ~ are expanded, and relative paths are resolved against the
+// directory of the file the key was read from.
 // If the key does not exist, ErrKeyNotFound is returned.
 func (c *Config) FilePath(key string) (val string, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return "", err
 	}
-	return filepath.Clean(str), nil
+	return c.resolvePath(key, str)
 }
 
 // FilePathOrDefault returns the value associated with the given key as a string that
@@ -374,6 +757,7 @@ func (c *Config) FilePathOrDefault(key string, def string) (val string, used boo
 	var err error
 	val, err = c.FilePath(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -385,23 +769,20 @@ func (c *Config) FilePathOrDefault(key string, def string) (val string, used boo
 // An error is returned if the value cannot be parsed into valid hour and minute components.
 // Hour must be greater than or equal to 0 and less than or equal to 23.
 // Minute must be greater than or equal to 0 and less than or equal to 59.
+// Use TimeOfDayValue for times of day with seconds or a time zone.
 func (c *Config) TimeOfDay(key string) (hour int, minute int, err error) {
 	str, err := c.String(key)
 	if err != nil {
 		return -1, -1, err
 	}
-	if n, err := fmt.Sscanf(str, "%d:%d", &hour, &minute); n != 2 {
-		return -1, -1, errors.New("invalid format")
-	} else if err != nil {
+	tod, err := ParseTimeOfDay(str)
+	if err != nil {
 		return -1, -1, err
 	}
-	if hour < 0 || 23 < hour {
-		return -1, -1, errors.New("hour component must be greater than or equal to 0 and less than or equal to 23")
-	}
-	if minute < 0 || 59 < minute {
-		return -1, -1, errors.New("minute component must be greater than or equal to 0 and less than or equal to 59")
+	if strings.Count(str, ":") != 1 || tod.Location != nil {
+		return -1, -1, fmt.Errorf("invalid time of day %q: expected HH:MM; use TimeOfDayValue for seconds and time zones", str)
 	}
-	return hour, minute, nil
+	return tod.Hour, tod.Minute, nil
 }
 
 // TimeOfDay returns the value associated with the given key as a string that
@@ -414,6 +795,7 @@ func (c *Config) TimeOfDayOrDefault(key string, defHour, defMinute int) (hour, m
 	var err error
 	hour, minute, err = c.TimeOfDay(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return defHour, defMinute, true
 	}
 	return hour, minute, false
@@ -441,6 +823,7 @@ func (c *Config) IPOrDefault(key string, def net.IP) (val net.IP, used bool) {
 	var err error
 	val, err = c.IP(key)
 	if err != nil {
+		c.defaulted(key, err)
 		return def, true
 	}
 	return val, false
@@ -478,40 +861,29 @@ func parseName(line string) string {
 // An error is returned if there is a problem reading or
 // unrecognized input.
 func Read(r io.Reader) (map[string]*Config, error) {
-	var m = make(map[string]*Config)
-	var cfg = &Config{
-		m: make(map[string]string),
+	cs, err := ReadConfigs(r)
+	if err != nil {
+		return nil, err
 	}
-	m[""] = cfg
+	return cs.m, nil
+}
 
-	var buf = bufio.NewReader(r)
-	var lnum uint
-	for {
-		var line, err = buf.ReadString('\n')
-		if err != nil && err != io.EOF {
+// ReadFile parses one or more Configs out of the named file.
+// Relative file paths in the file can be resolved against its directory,
+// see SetPathOptions.
+func ReadFile(path string) (map[string]*Config, error) {
+	return ReadFiles(path)
+}
+
+// ReadFiles parses the named files in order and combines their Configs.
+// Configs with the same name are merged, with key/value pairs from later
+// files replacing those from earlier ones.
+func ReadFiles(paths ...string) (map[string]*Config, error) {
+	var cs = NewConfigs()
+	for _, path := range paths {
+		if err := cs.readFile(path); err != nil {
 			return nil, err
 		}
-		lnum = lnum + 1
-		line = strings.TrimSpace(line)
-		if isComment(line) || isEmpty(line) {
-			// ignore
-		} else if isKeyValue(line) {
-			var key, value = parseKeyValue(line)
-			cfg.m[key] = value
-		} else if isName(line) {
-			var name = parseName(line)
-			if _, prs := m[name]; !prs {
-				m[name] = &Config{
-					m: make(map[string]string),
-				}
-			}
-			cfg = m[name]
-		} else {
-			return nil, fmt.Errorf("unrecognized input at line %d: %s", lnum, line)
-		}
-		if err != nil && err == io.EOF {
-			break
-		}
 	}
-	return m, nil
+	return cs.m, nil
 }
@@ -1723,3 +1723,162 @@ func TestConfig_IPOrDefault(t *testing.T) {
 		}
 	})
 }
+
+func TestConfig_integerLiterals(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out int64
+		err bool
+	}{
+		{"1234", 1234, false},
+		{"-1234", -1234, false},
+		{"0x1F", 31, false},
+		{"0X1f", 31, false},
+		{"0o755", 493, false},
+		{"0755", 755, false},
+		{"010", 10, false},
+		{"08080", 8080, false},
+		{"-010", -10, false},
+		{"+007", 7, false},
+		{"00", 0, false},
+		{"0b101", 5, false},
+		{"1_000_000", 1000000, false},
+		{"0x_FF", 255, false},
+		{"0", 0, false},
+		{"1__000", 0, true},
+		{"_1000", 0, true},
+		{"0x", 0, true},
+		{"08", 8, false},
+		{"0_17", 17, false},
+		{"0_0", 0, false},
+		{"00x10", 0, true},
+		{"00b1", 0, true},
+		{"-00o7", 0, true},
+		{"0__17", 0, true},
+		{"017_", 0, true},
+		{"12abc", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			cfg := FromMap(map[string]string{"int": test.in})
+			val, err := cfg.Int64("int")
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %d", val)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect an error: %s", err)
+			}
+			if val != test.out {
+				t.Errorf("expected %d but got %d", test.out, val)
+			}
+			if i, err := cfg.Int("int"); err != nil || int64(i) != test.out {
+				t.Errorf("expected Int to return %d but got %d (%v)", test.out, i, err)
+			}
+		})
+	}
+
+	if _, err := FromMap(map[string]string{"int": "00x10"}).Int("int"); err == nil || !strings.Contains(err.Error(), `"00x10"`) {
+		t.Errorf("expected the error to quote the value as written but got %v", err)
+	}
+	if val, used := FromMap(map[string]string{"port": "08080"}).IntOrDefault("port", 80); used || val != 8080 {
+		t.Errorf("expected 8080 but got %d", val)
+	}
+
+	cfg := FromMap(map[string]string{"uint": "0xFFFF_FFFF"})
+	if val, err := cfg.Uint32("uint"); err != nil || val != 0xFFFFFFFF {
+		t.Errorf("expected %d but got %d (%v)", uint32(0xFFFFFFFF), val, err)
+	}
+}
+
+func TestConfig_SetDecimalIntegers(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+	mode = 0640
+	mask = 0x1F
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cs.Config("")
+
+	if val, _ := cfg.Int("mode"); val != 640 {
+		t.Errorf("expected 640 but got %d", val)
+	}
+	if val, _ := cfg.Uint("mask"); val != 31 {
+		t.Errorf("expected 31 but got %d", val)
+	}
+
+	cs.SetDecimalIntegers(true)
+	if val, _ := cfg.Int("mode"); val != 640 {
+		t.Errorf("expected 640 but got %d", val)
+	}
+	if _, err := cfg.Uint("mask"); err == nil {
+		t.Error("expected an error but did not get one")
+	}
+	if val, used := GetOrDefault(cfg, "mode", 0); val != 640 || used {
+		t.Errorf("expected GetOrDefault to return 640 but got %d", val)
+	}
+	if !cfg.Clone().Equal(cfg) || cfg.Clone().decimal != true {
+		t.Error("expected Clone to keep decimal integers")
+	}
+}
+
+func TestConfig_smallIntegers(t *testing.T) {
+	cfg := FromMap(map[string]string{
+		"i8":      "-128",
+		"i16":     "0x7FFF",
+		"u8":      "255",
+		"u16":     "65_535",
+		"over_i8": "128",
+		"over_u8": "256",
+		"neg":     "-1",
+	})
+
+	t.Run("Int8", func(t *testing.T) {
+		if val, err := cfg.Int8("i8"); err != nil || val != -128 {
+			t.Errorf("expected -128 but got %d (%v)", val, err)
+		}
+		if _, err := cfg.Int8("over_i8"); err == nil {
+			t.Error("expected an error but did not get one")
+		}
+		if val, used := cfg.Int8OrDefault("over_i8", 7); val != 7 || !used {
+			t.Errorf("expected default 7 but got %d", val)
+		}
+	})
+
+	t.Run("Int16", func(t *testing.T) {
+		if val, err := cfg.Int16("i16"); err != nil || val != 32767 {
+			t.Errorf("expected 32767 but got %d (%v)", val, err)
+		}
+		if val, used := cfg.Int16OrDefault("missing", 7); val != 7 || !used {
+			t.Errorf("expected default 7 but got %d", val)
+		}
+	})
+
+	t.Run("Uint8", func(t *testing.T) {
+		if val, err := cfg.Uint8("u8"); err != nil || val != 255 {
+			t.Errorf("expected 255 but got %d (%v)", val, err)
+		}
+		if _, err := cfg.Uint8("over_u8"); err == nil {
+			t.Error("expected an error but did not get one")
+		}
+		if val, used := cfg.Uint8OrDefault("neg", 7); val != 7 || !used {
+			t.Errorf("expected default 7 but got %d", val)
+		}
+	})
+
+	t.Run("Uint16", func(t *testing.T) {
+		if val, err := cfg.Uint16("u16"); err != nil || val != 65535 {
+			t.Errorf("expected 65535 but got %d (%v)", val, err)
+		}
+		if val, used := cfg.Uint16OrDefault("u16", 7); val != 65535 || used {
+			t.Errorf("expected 65535 but got %d", val)
+		}
+		if _, err := cfg.Uint16("missing"); err != ErrKeyNotFound {
+			t.Error("expected 'ErrKeyNotFound'")
+		}
+	})
+}
@@ -0,0 +1,214 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Configs is a set of named Configs, as read from a configuration file.
+// It remembers the order in which the configurations appeared.
+// The zero value is an empty Configs ready to use.
+type Configs struct {
+	m     map[string]*Config
+	names []string
+}
+
+// NewConfigs returns a Configs holding only an empty default configuration, "".
+func NewConfigs() *Configs {
+	var cs = &Configs{}
+	cs.Section("")
+	return cs
+}
+
+// ConfigsFromMap returns a Configs holding the Configs in m, such as those
+// returned by Read. The default configuration, "", is added if it is missing,
+// and nil Configs are replaced with empty ones, as with Set.
+// Since maps are unordered, Sections reports the names in sorted order
+// after "".
+func ConfigsFromMap(m map[string]*Config) *Configs {
+	var cs = NewConfigs()
+	var names = make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cs.Set(name, m[name])
+	}
+	return cs
+}
+
+// ReadConfigs parses one or more Configs out of the given io.Reader.
+// It is like Read but also records the order of the configurations.
+// An error is returned if there is a problem reading or
+// unrecognized input.
+func ReadConfigs(r io.Reader) (*Configs, error) {
+	var cs = NewConfigs()
+	if err := cs.read(r, ""); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// lazyInit adds the default configuration, "", to a zero Configs.
+func (cs *Configs) lazyInit() {
+	if cs.m == nil {
+		cs.m = map[string]*Config{"": New()}
+		cs.names = []string{""}
+	}
+}
+
+// Section returns the named Config, adding an empty one if it does not exist.
+func (cs *Configs) Section(name string) *Config {
+	cs.lazyInit()
+	if cfg, prs := cs.m[name]; prs {
+		return cfg
+	}
+	var cfg = New()
+	cs.Set(name, cfg)
+	return cfg
+}
+
+// Set adds the Config under the given name, replacing any Config already
+// using that name. A nil cfg adds an empty Config.
+//
+// The Config takes on the name. Configs are not copied, so if cfg also belongs
+// to another Configs, it is renamed there too.
+func (cs *Configs) Set(name string, cfg *Config) {
+	cs.lazyInit()
+	if cfg == nil {
+		cfg = New()
+	}
+	if _, prs := cs.m[name]; !prs {
+		cs.names = append(cs.names, name)
+	}
+	cfg.mu.Lock()
+	cfg.name = name
+	cfg.mu.Unlock()
+	cs.m[name] = cfg
+}
+
+// readFile parses key/value pairs out of the named file into cs, as read would,
+// recording the directory of the file.
+func (cs *Configs) readFile(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := cs.read(f, filepath.Dir(abs)); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// read parses key/value pairs out of r into cs, replacing the values of
+// keys that already exist. dir, if not "", is recorded as the directory the
+// keys were read from.
+func (cs *Configs) read(r io.Reader, dir string) error {
+	var cfg = cs.Section("")
+	var buf = bufio.NewReader(r)
+	var lnum uint
+	for {
+		var line, err = buf.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		lnum = lnum + 1
+		line = strings.TrimSpace(line)
+		if isComment(line) || isEmpty(line) {
+			// ignore
+		} else if isKeyValue(line) {
+			var key, value = parseKeyValue(line)
+			cfg.set(key, value)
+			if dir != "" {
+				if cfg.dirs == nil {
+					cfg.dirs = make(map[string]string)
+				}
+				cfg.dirs[key] = dir
+			}
+		} else if isName(line) {
+			cfg = cs.Section(parseName(line))
+		} else {
+			return fmt.Errorf("unrecognized input at line %d: %s", lnum, line)
+		}
+		if err != nil && err == io.EOF {
+			break
+		}
+	}
+	return nil
+}
+
+// Sections returns the names of the configurations in the order they first
+// appeared. The default configuration, "", is always first.
+func (cs *Configs) Sections() []string {
+	cs.lazyInit()
+	return append([]string(nil), cs.names...)
+}
+
+// Config returns the named Config, or nil if it does not exist.
+func (cs *Configs) Config(name string) *Config {
+	cs.lazyInit()
+	return cs.m[name]
+}
+
+// Map returns the Configs keyed by name, as returned by Read.
+func (cs *Configs) Map() map[string]*Config {
+	cs.lazyInit()
+	return cs.m
+}
+
+// SetDecimalIntegers calls SetDecimalIntegers on every Config.
+func (cs *Configs) SetDecimalIntegers(on bool) {
+	cs.lazyInit()
+	for _, name := range cs.names {
+		cs.m[name].SetDecimalIntegers(on)
+	}
+}
+
+// SetPathOptions calls SetPathOptions on every Config.
+func (cs *Configs) SetPathOptions(opts PathOptions) {
+	cs.lazyInit()
+	for _, name := range cs.names {
+		cs.m[name].SetPathOptions(opts)
+	}
+}
+
+// SetBaseDir calls SetBaseDir on every Config.
+func (cs *Configs) SetBaseDir(dir string) {
+	cs.lazyInit()
+	for _, name := range cs.names {
+		cs.m[name].SetBaseDir(dir)
+	}
+}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigs(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+zulu = 1
+alpha = 2
+
+log:
+	path = ../out/log.txt
+database:
+	username = admin
+log:
+	level = fatal
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Run("Sections", func(t *testing.T) {
+		var exp = []string{"", "log", "database"}
+		if got := cs.Sections(); !reflect.DeepEqual(got, exp) {
+			t.Errorf("expected %#v but got %#v", exp, got)
+		}
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		if cfg := cs.Config("database"); cfg == nil || cfg.Name() != "database" {
+			t.Error("expected the 'database' config")
+		}
+		if cs.Config("missing") != nil {
+			t.Error("did not expect a 'missing' config")
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		var exp = []string{"path", "level"}
+		if got := cs.Config("log").Keys(); !reflect.DeepEqual(got, exp) {
+			t.Errorf("expected %#v but got %#v", exp, got)
+		}
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		if m := cs.Map(); len(m) != 3 || m["log"] != cs.Config("log") {
+			t.Errorf("expected the 3 configs but got %v", m)
+		}
+	})
+
+	_, err = ReadConfigs(strings.NewReader("nope"))
+	t.Run("invalid", func(t *testing.T) {
+		if err == nil {
+			t.Error("expected an error but did not get one")
+		}
+	})
+}
+
+func TestConfig_Keys(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	zulu = 1
+	alpha = 2
+	mike = 3
+	alpha = 4
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var exp = []string{"zulu", "alpha", "mike"}
+	if got := cfg.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %#v but got %#v", exp, got)
+	}
+
+	cfg.Set("bravo", "5")
+	cfg.Set("zulu", "6")
+	cfg.Delete("alpha")
+	cfg.Delete("missing")
+	exp = []string{"zulu", "mike", "bravo"}
+	if got := cfg.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %#v but got %#v", exp, got)
+	}
+
+	t.Run("Len", func(t *testing.T) {
+		if cfg.Len() != 3 {
+			t.Errorf("expected 3 but got %d", cfg.Len())
+		}
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		if !cfg.Has("mike") {
+			t.Error("expected to have 'mike'")
+		}
+		if cfg.Has("alpha") {
+			t.Error("did not expect to have 'alpha'")
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		var got []string
+		cfg.All()(func(key, val string) bool {
+			got = append(got, key+"="+val)
+			return true
+		})
+		var exp = []string{"zulu=6", "mike=3", "bravo=5"}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("expected %#v but got %#v", exp, got)
+		}
+	})
+
+	t.Run("All/stop", func(t *testing.T) {
+		var n int
+		cfg.All()(func(key, val string) bool {
+			n++
+			return false
+		})
+		if n != 1 {
+			t.Errorf("expected iteration to stop after 1 pair but got %d", n)
+		}
+	})
+}
+
+func TestConfig_Delete(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	port = 1234
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var port int
+	IntVar(&port, cfg, "port", 8080)
+	cfg.Delete("port")
+	if _, err := cfg.String("port"); err != ErrKeyNotFound {
+		t.Error("expected 'ErrKeyNotFound'")
+	}
+	if port != 8080 {
+		t.Errorf("expected bound variable to be the default 8080 but got %d", port)
+	}
+}
+
+func TestConfig_zero(t *testing.T) {
+	var cfg Config
+	if _, err := cfg.String("a"); err != ErrKeyNotFound {
+		t.Error("expected 'ErrKeyNotFound'")
+	}
+	cfg.Set("a", "b")
+	if val, err := cfg.String("a"); err != nil || val != "b" {
+		t.Errorf("expected 'b' but got '%s' (%v)", val, err)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	cfg := FromMap(map[string]string{
+		"port": "5432",
+		"host": "localhost",
+	})
+	var exp = []string{"host", "port"}
+	if got := cfg.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %#v but got %#v", exp, got)
+	}
+	if port, _ := cfg.IntOrDefault("port", 0); port != 5432 {
+		t.Errorf("expected 5432 but got %d", port)
+	}
+	if New().Len() != 0 {
+		t.Error("expected New to return an empty Config")
+	}
+}
+
+func TestConfigsFromMap(t *testing.T) {
+	var db = FromMap(map[string]string{"username": "admin"})
+	cs := ConfigsFromMap(map[string]*Config{
+		"log":      New(),
+		"database": db,
+		"metrics":  nil,
+	})
+	var exp = []string{"", "database", "log", "metrics"}
+	if got := cs.Sections(); !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %#v but got %#v", exp, got)
+	}
+	if metrics := cs.Config("metrics"); metrics == nil || metrics.Name() != "metrics" {
+		t.Error("expected an empty 'metrics' config in place of nil")
+	}
+	cs.Set("cache", nil)
+	if cs.Config("cache") == nil {
+		t.Error("expected Set to add an empty 'cache' config in place of nil")
+	}
+	if cs.Config("database") != db || db.Name() != "database" {
+		t.Error("expected the 'database' config to be named 'database'")
+	}
+
+	var zero Configs
+	zero.Section("metrics").Set("interval", "10s")
+	if zero.Config("metrics") == nil || !reflect.DeepEqual(zero.Sections(), []string{"", "metrics"}) {
+		t.Errorf("expected the default and 'metrics' configs but got %#v", zero.Sections())
+	}
+
+	var empty Configs
+	if empty.Config("") == nil || !reflect.DeepEqual(empty.Sections(), []string{""}) {
+		t.Errorf("expected the default config but got %#v", empty.Sections())
+	}
+	if !empty.Equal(NewConfigs()) {
+		t.Error("expected a zero Configs to equal NewConfigs()")
+	}
+}
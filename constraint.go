@@ -0,0 +1,203 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ErrConstraint returned when a value does not satisfy a constraint declared in a Schema
+var ErrConstraint = errors.New("constraint not satisfied")
+
+// check tests a parsed value, and the text it was parsed from, against a constraint.
+type check func(val interface{}, raw string) error
+
+func (ks *KeySchema) addCheck(c check) *KeySchema {
+	ks.checks = append(ks.checks, c)
+	return ks
+}
+
+// Min requires the value to be greater than or equal to min.
+// It applies to numbers and durations, and min may be of any numeric type,
+// e.g. Min(1) for an int or Min(time.Second) for a duration.
+func (ks *KeySchema) Min(min interface{}) *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		cmp, ok := compareNumbers(val, min)
+		if !ok {
+			return fmt.Errorf("%w: cannot compare %s to minimum %v", ErrConstraint, ks.t, min)
+		}
+		if cmp < 0 {
+			return fmt.Errorf("%w: must be at least %v", ErrConstraint, min)
+		}
+		return nil
+	})
+}
+
+// Max requires the value to be less than or equal to max.
+// It applies to numbers and durations, and max may be of any numeric type.
+func (ks *KeySchema) Max(max interface{}) *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		cmp, ok := compareNumbers(val, max)
+		if !ok {
+			return fmt.Errorf("%w: cannot compare %s to maximum %v", ErrConstraint, ks.t, max)
+		}
+		if cmp > 0 {
+			return fmt.Errorf("%w: must be at most %v", ErrConstraint, max)
+		}
+		return nil
+	})
+}
+
+// OneOf requires the value, as written, to be one of the given values.
+func (ks *KeySchema) OneOf(vals ...string) *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		for _, v := range vals {
+			if raw == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: must be one of %s", ErrConstraint, strings.Join(vals, ", "))
+	})
+}
+
+// Match requires the whole value, as written, to match the regular expression pattern.
+// It panics if pattern cannot be compiled.
+func (ks *KeySchema) Match(pattern string) *KeySchema {
+	var re = regexp.MustCompile(`^(?:` + pattern + `)$`)
+	return ks.addCheck(func(val interface{}, raw string) error {
+		if !re.MatchString(raw) {
+			return fmt.Errorf("%w: must match %s", ErrConstraint, pattern)
+		}
+		return nil
+	})
+}
+
+// Schemes requires a URL to use one of the given schemes, e.g. "https".
+// Schemes are compared without regard to case.
+func (ks *KeySchema) Schemes(schemes ...string) *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		u, ok := val.(*url.URL)
+		if !ok {
+			return fmt.Errorf("%w: %s has no scheme", ErrConstraint, ks.t)
+		}
+		for _, s := range schemes {
+			if strings.EqualFold(u.Scheme, s) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: scheme must be one of %s", ErrConstraint, strings.Join(schemes, ", "))
+	})
+}
+
+// IPv4 requires an IP to be an IPv4 address.
+func (ks *KeySchema) IPv4() *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		if ip, ok := val.(net.IP); !ok || ip.To4() == nil {
+			return fmt.Errorf("%w: must be an IPv4 address", ErrConstraint)
+		}
+		return nil
+	})
+}
+
+// IPv6 requires an IP to be an IPv6 address.
+func (ks *KeySchema) IPv6() *KeySchema {
+	return ks.addCheck(func(val interface{}, raw string) error {
+		if ip, ok := val.(net.IP); !ok || ip.To4() != nil {
+			return fmt.Errorf("%w: must be an IPv6 address", ErrConstraint)
+		}
+		return nil
+	})
+}
+
+// compareNumbers compares two values of any integer, unsigned integer or
+// floating point kind, returning -1, 0 or 1 as a is less than, equal to or
+// greater than b. ok is false if either value is not a number.
+func compareNumbers(a, b interface{}) (cmp int, ok bool) {
+	var va, vb = reflect.ValueOf(a), reflect.ValueOf(b)
+	var ka, kb = numberKind(va), numberKind(vb)
+	if ka == reflect.Invalid || kb == reflect.Invalid {
+		return 0, false
+	}
+	switch {
+	case ka == reflect.Int && kb == reflect.Int:
+		return compare(va.Int(), vb.Int()), true
+	case ka == reflect.Uint && kb == reflect.Uint:
+		return compare(va.Uint(), vb.Uint()), true
+	case ka == reflect.Int && kb == reflect.Uint:
+		if va.Int() < 0 {
+			return -1, true
+		}
+		return compare(uint64(va.Int()), vb.Uint()), true
+	case ka == reflect.Uint && kb == reflect.Int:
+		if vb.Int() < 0 {
+			return 1, true
+		}
+		return compare(va.Uint(), uint64(vb.Int())), true
+	}
+	var fa, fb = toFloat(va), toFloat(vb)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return 0, false
+	}
+	return compare(fa, fb), true
+}
+
+// numberKind returns reflect.Int, reflect.Uint or reflect.Float64 for values
+// of signed, unsigned and floating point kinds, and reflect.Invalid otherwise.
+func numberKind(v reflect.Value) reflect.Kind {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch numberKind(v) {
+	case reflect.Int:
+		return float64(v.Int())
+	case reflect.Uint:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func compare[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
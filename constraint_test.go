@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchema_constraints(t *testing.T) {
+	var s = NewSchema()
+	var sec = s.Section("")
+	sec.Key("port", TypeInt).Min(1).Max(65535)
+	sec.Key("timeout", TypeDuration).Min(time.Second).Max(time.Minute)
+	sec.Key("ratio", TypeFloat64).Min(0).Max(1)
+	sec.Key("workers", TypeUint).Min(-1).Max(uint64(8))
+	sec.Key("level", TypeString).OneOf("debug", "info", "warn", "error", "fatal")
+	sec.Key("name", TypeString).Match(`[a-z][a-z0-9-]*`)
+	sec.Key("endpoint", TypeURL).Schemes("https")
+	sec.Key("v4", TypeIP).IPv4()
+	sec.Key("v6", TypeIP).IPv6()
+	sec.Key("label", TypeString).Min(1)
+
+	var tests = []struct {
+		key   string
+		good  string
+		bad   string
+		inErr string
+	}{
+		{"port", "65535", "0", "at least 1"},
+		{"timeout", "30s", "2m", "at most 1m0s"},
+		{"ratio", "0.5", "1.5", "at most 1"},
+		{"workers", "8", "9", "at most 8"},
+		{"level", "warn", "verbose", "one of debug, info, warn, error, fatal"},
+		{"name", "api-1", "api-1!", "must match"},
+		{"endpoint", "HTTPS://example.com", "http://example.com", "scheme must be one of https"},
+		{"v4", "10.0.0.1", "::1", "IPv4"},
+		{"v6", "::1", "10.0.0.1", "IPv6"},
+		{"label", "", "x", "cannot compare"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			var good = FromMap(map[string]string{test.key: test.good})
+			if test.key != "label" {
+				if err := s.Validate(map[string]*Config{"": good}).Err(); err != nil {
+					t.Errorf("did not expect an error for %q: %s", test.good, err)
+				}
+			}
+
+			var bad = FromMap(map[string]string{test.key: test.bad})
+			var err = s.Validate(map[string]*Config{"": bad}).Err()
+			if !errors.Is(err, ErrConstraint) {
+				t.Fatalf("expected ErrConstraint for %q but got %v", test.bad, err)
+			}
+			var ve *ValueError
+			if !errors.As(err, &ve) || ve.Key != test.key || ve.Value != test.bad {
+				t.Errorf("expected a *ValueError naming %q but got %v", test.key, err)
+			}
+			if !strings.Contains(err.Error(), test.inErr) {
+				t.Errorf("expected %q in the error but got %s", test.inErr, err)
+			}
+		})
+	}
+}
+
+func TestCompareNumbers(t *testing.T) {
+	var tests = []struct {
+		a, b interface{}
+		cmp  int
+		ok   bool
+	}{
+		{1, 2, -1, true},
+		{int64(-1), uint(0), -1, true},
+		{uint64(1 << 63), int64(1), 1, true},
+		{2.5, 2, 1, true},
+		{time.Second, 1000000000, 0, true},
+		{"1", 1, 0, false},
+	}
+	for _, test := range tests {
+		cmp, ok := compareNumbers(test.a, test.b)
+		if cmp != test.cmp || ok != test.ok {
+			t.Errorf("compareNumbers(%v, %v): expected %d, %t but got %d, %t", test.a, test.b, test.cmp, test.ok, cmp, ok)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a schedule described by a cron expression, such as "*/15 9-17 * * Mon-Fri",
+// "@daily" or "@every 5m".
+type Cron struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64 // bit sets of the values each field matches
+	domAny, dowAny                bool   // whether the day fields are unrestricted
+	every                         time.Duration
+}
+
+// cronField describes one of the five fields of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var cronShorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression. Standard expressions have five fields
+// separated by whitespace: minute (0-59), hour (0-23), day of month (1-31),
+// month (1-12 or Jan-Dec) and day of week (0-7 or Sun-Sat, where 0 and 7
+// are both Sunday). Each field is "*" or a list of values and ranges separated
+// by ',', e.g. "1,15" or "Mon-Fri", each of which may be followed by a step
+// such as "*/15" or "0-30/10". As with cron, if both day fields are
+// restricted, a day matches if either field does; a day field starting with
+// "*", such as "*/2", does not count as restricted.
+//
+// The shorthands @yearly (or @annually), @monthly, @weekly, @daily (or
+// @midnight) and @hourly are accepted, as is "@every DURATION", where
+// DURATION is in the form time.ParseDuration accepts, e.g. "@every 5m".
+func ParseCron(s string) (Cron, error) {
+	var spec = strings.Join(strings.Fields(s), " ")
+	var c = Cron{spec: spec}
+	if len(spec) > len("@every ") && strings.EqualFold(spec[:len("@every ")], "@every ") {
+		d, err := time.ParseDuration(spec[len("@every "):])
+		if err != nil {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: %w", s, err)
+		}
+		if d <= 0 {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: duration must be positive", s)
+		}
+		c.every = d
+		return c, nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if spec, ok = cronShorthands[strings.ToLower(spec)]; !ok {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: unknown shorthand", s)
+		}
+	}
+
+	var fields = strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return Cron{}, fmt.Errorf("invalid cron expression %q: expected 5 fields but got %d", s, len(fields))
+	}
+	var sets = [5]*uint64{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, field := range fields {
+		set, err := cronFields[i].parse(field)
+		if err != nil {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: %s field %q: %w", s, cronFields[i].name, field, err)
+		}
+		*sets[i] = set
+	}
+	// 7 is another name for Sunday
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	// as in cron, a day field starting with "*", such as "*/2", is unrestricted
+	c.domAny, c.dowAny = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// parse parses a field into the set of values it matches.
+func (f cronField) parse(field string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		var rng, step = item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			rng = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", item[i+1:])
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rng, "-"):
+			var bounds = strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("range %q is backwards", rng)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if step > 1 {
+				// as in cron, "5/15" means "5-59/15"
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name and checks it is in range.
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	if !isDigits(s) {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || f.max < v {
+		return 0, fmt.Errorf("%s is out of range %d-%d", s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the cron expression the Cron was parsed from, with
+// whitespace normalized.
+func (c Cron) String() string {
+	return c.spec
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Cron) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Cron) UnmarshalText(text []byte) error {
+	cron, err := ParseCron(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cron
+	return nil
+}
+
+// Equal reports whether c and d describe the same schedule, so "@daily"
+// and "0 0 * * *" are equal.
+func (c Cron) Equal(d Cron) bool {
+	c.spec, d.spec = "", ""
+	return c == d
+}
+
+// Next returns the first time after t that matches the schedule, in t's
+// time zone, or the zero time.Time if there is none within five years, as
+// for "0 0 30 2 *". Schedules made with @every return t plus the duration.
+func (c Cron) Next(t time.Time) time.Time {
+	if c.every > 0 {
+		return t.Add(c.every)
+	}
+	if c.minute == 0 {
+		return time.Time{}
+	}
+
+	var loc = t.Location()
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	var limit = t.Year() + 5
+	for t.Year() <= limit {
+		var year, month, day = t.Date()
+		switch {
+		case c.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchesDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c Cron) matchesDay(t time.Time) bool {
+	var dom = c.dom&(1<<uint(t.Day())) != 0
+	var dow = c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Cron returns the value associated with the given key as a Cron,
+// parsed as ParseCron would.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error naming the invalid field is returned if the value cannot be parsed into a Cron.
+func (c *Config) Cron(key string) (val Cron, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return Cron{}, err
+	}
+	return ParseCron(str)
+}
+
+// CronOrDefault returns the value associated with the given key as a Cron.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) CronOrDefault(key string, def Cron) (val Cron, used bool) {
+	var err error
+	val, err = c.Cron(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
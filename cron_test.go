@@ -0,0 +1,156 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	var tests = []struct {
+		in  string
+		err string
+	}{
+		{"* * * * *", ""},
+		{"*/15 9-17 * * Mon-Fri", ""},
+		{"0 0 1,15 jan-jun,DEC 0-7", ""},
+		{"5/20 * * * *", ""},
+		{"0  0   * * *", ""},
+		{"@daily", ""},
+		{"@HOURLY", ""},
+		{"@every 5m", ""},
+		{"@every 1h30m", ""},
+		{"@Every 5m", ""},
+		{"* * * *", "expected 5 fields"},
+		{"* * * * * *", "expected 5 fields"},
+		{"60 * * * *", `minute field "60": 60 is out of range 0-59`},
+		{"0 24 * * *", `hour field "24"`},
+		{"0 0 0 * *", `day of month field "0"`},
+		{"0 0 * 13 *", `month field "13"`},
+		{"0 0 * * 8", `day of week field "8"`},
+		{"0 0 * * Funday", `day of week field "Funday": invalid value`},
+		{"5-1 * * * *", `minute field "5-1": range "5-1" is backwards`},
+		{"*/0 * * * *", `minute field "*/0": invalid step`},
+		{"1-2-3 * * * *", `minute field "1-2-3"`},
+		{"-1 * * * *", `minute field "-1"`},
+		{"@fortnightly", "unknown shorthand"},
+		{"@every", "unknown shorthand"},
+		{"@every soon", "invalid duration"},
+		{"@every -5m", "must be positive"},
+		{"", "expected 5 fields"},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			c, err := ParseCron(test.in)
+			if test.err == "" {
+				if err != nil {
+					t.Errorf("did not expect an error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error but got %s", c)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected the error to contain %q but got %q", test.err, err)
+			}
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// Friday 2026-10-16 10:07:30 UTC
+	var now = time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	var tests = []struct {
+		spec string
+		exp  time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 16, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{"7 10 * * *", time.Date(2026, 10, 17, 10, 7, 0, 0, time.UTC)},
+		{"0 9-17 * * Mon-Fri", time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{"30 8 * * Mon-Fri", time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// both day fields restricted: either may match
+		{"0 0 1 * Sun", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		// a stepped "*" does not restrict the day: odd days that are Mondays
+		{"0 0 */2 * Mon", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 5m", now.Add(5 * time.Minute)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			c, err := ParseCron(test.spec)
+			if err != nil {
+				t.Fatalf("did not expect an error: %s", err)
+			}
+			if got := c.Next(now); !got.Equal(test.exp) {
+				t.Errorf("expected %s but got %s", test.exp, got)
+			}
+		})
+	}
+
+	t.Run("time zone", func(t *testing.T) {
+		var east = time.FixedZone("UTC+5:30", 5*60*60+30*60)
+		var c, _ = ParseCron("0 * * * *")
+		var got = c.Next(now.In(east))
+		if got.Location() != east || got.Minute() != 0 || !got.Equal(time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)) {
+			t.Errorf("expected the top of the next hour at UTC+5:30 but got %s", got)
+		}
+	})
+
+	t.Run("stepped day of month", func(t *testing.T) {
+		var c, _ = ParseCron("0 0 */2 * Mon")
+		var after = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		if got, exp := c.Next(after), time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC); !got.Equal(exp) {
+			t.Errorf("expected %s but got %s", exp, got)
+		}
+	})
+
+	t.Run("exact minute", func(t *testing.T) {
+		var c, _ = ParseCron("0 0 * * *")
+		var midnight = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+		if got := c.Next(midnight); !got.Equal(midnight.AddDate(0, 0, 1)) {
+			t.Errorf("expected the next day but got %s", got)
+		}
+	})
+}
+
+func TestConfig_Cron(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	backup = 0 2 * * *
+	poll = @every 30s
+	bad = 0 25 * * *
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var daily, _ = ParseCron("@daily")
+	if c, err := cfg.Cron("backup"); err != nil || c.String() != "0 2 * * *" || c.Equal(daily) {
+		t.Errorf("expected 0 2 * * * but got %s (%v)", c, err)
+	}
+	if c, err := Get[Cron](cfg, "poll"); err != nil || c.String() != "@every 30s" {
+		t.Errorf("expected @every 30s but got %s (%v)", c, err)
+	}
+	if _, err := cfg.Cron("bad"); err == nil || !strings.Contains(err.Error(), "hour field") {
+		t.Errorf("expected an error naming the hour field but got %v", err)
+	}
+	if c, used := cfg.CronOrDefault("bad", daily); !used || !c.Equal(daily) {
+		t.Errorf("expected the default but got %s", c)
+	}
+
+	var a, b = New(), New()
+	a.Set("backup", "@daily")
+	b.Set("backup", "0 0 * * *")
+	if d := a.Diff(b, Types{"backup": TypeCron}); !d.Empty() {
+		t.Errorf("expected equivalent expressions to be equal but got %+v", d)
+	}
+}
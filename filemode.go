@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ParseFileMode parses file permissions written in octal, such as "0640",
+// "0o640" or "640", in the symbolic form shown by ls, such as "rw-r-----", or
+// as chmod style clauses, such as "u=rw,g=r". Setuid, setgid and sticky bits
+// are supported in every form, e.g. "4755", "rwsr-xr-x" or "u=rwxs,go=rx".
+// An error is returned for any bits other than permissions and those three.
+func ParseFileMode(s string) (os.FileMode, error) {
+	var str = strings.TrimSpace(s)
+	if str == "" {
+		return 0, fmt.Errorf("invalid file mode %q", s)
+	}
+	var (
+		mode os.FileMode
+		err  error
+	)
+	switch {
+	case strings.Trim(str, "01234567oO") == "":
+		mode, err = parseOctalMode(str)
+	case len(str) == 9 && strings.Trim(str, "rwxsStT-") == "":
+		mode, err = parseLsMode(str)
+	default:
+		mode, err = parseSymbolicMode(str)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: %w", s, err)
+	}
+	return mode, nil
+}
+
+func parseOctalMode(str string) (os.FileMode, error) {
+	var digits = strings.TrimPrefix(strings.TrimPrefix(str, "0o"), "0O")
+	n, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if n > 07777 {
+		return 0, fmt.Errorf("bits other than permissions, setuid, setgid and sticky are set")
+	}
+	var mode = os.FileMode(n & 0777)
+	if n&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if n&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if n&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode, nil
+}
+
+// parseLsMode parses the nine permission characters shown by ls -l.
+func parseLsMode(str string) (os.FileMode, error) {
+	var mode os.FileMode
+	for i, r := range str {
+		var bit = os.FileMode(1) << uint(8-i)
+		var want = "rwx"[i%3]
+		switch {
+		case r == '-':
+		case byte(r) == want:
+			mode |= bit
+		case i%3 == 2 && (r == 's' || r == 'S') && i != 8:
+			if r == 's' {
+				mode |= bit
+			}
+			if i == 2 {
+				mode |= os.ModeSetuid
+			} else {
+				mode |= os.ModeSetgid
+			}
+		case i == 8 && (r == 't' || r == 'T'):
+			if r == 't' {
+				mode |= bit
+			}
+			mode |= os.ModeSticky
+		default:
+			return 0, fmt.Errorf("unexpected %q at position %d", r, i+1)
+		}
+	}
+	return mode, nil
+}
+
+// parseSymbolicMode parses comma separated chmod style clauses, starting
+// from no permissions.
+func parseSymbolicMode(str string) (os.FileMode, error) {
+	var mode os.FileMode
+	for _, clause := range strings.Split(str, ",") {
+		var i = strings.IndexAny(clause, "=+-")
+		if i < 0 {
+			return 0, fmt.Errorf("clause %q has no operator", clause)
+		}
+		var who, op, perms = clause[:i], clause[i], clause[i+1:]
+		if who == "" {
+			who = "a"
+		}
+
+		var mask, bits os.FileMode
+		for _, w := range who {
+			switch w {
+			case 'u':
+				mask |= 0700 | os.ModeSetuid
+			case 'g':
+				mask |= 0070 | os.ModeSetgid
+			case 'o':
+				mask |= 0007 | os.ModeSticky
+			case 'a':
+				mask |= 0777 | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+			default:
+				return 0, fmt.Errorf("unexpected %q in clause %q", w, clause)
+			}
+		}
+		for _, p := range perms {
+			switch p {
+			case 'r':
+				bits |= 0444
+			case 'w':
+				bits |= 0222
+			case 'x':
+				bits |= 0111
+			case 's':
+				bits |= os.ModeSetuid | os.ModeSetgid
+			case 't':
+				bits |= os.ModeSticky
+			default:
+				return 0, fmt.Errorf("unexpected %q in clause %q", p, clause)
+			}
+		}
+		bits &= mask
+
+		switch op {
+		case '=':
+			mode = mode&^mask | bits
+		case '+':
+			mode |= bits
+		case '-':
+			mode &^= bits
+		}
+	}
+	return mode, nil
+}
+
+// FileMode returns the value associated with the given key as an os.FileMode,
+// as parsed by ParseFileMode, e.g. "0640", "rw-r-----" or "u=rw,g=r".
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into an os.FileMode.
+func (c *Config) FileMode(key string) (val os.FileMode, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return 0, err
+	}
+	return ParseFileMode(str)
+}
+
+// FileModeOrDefault returns the value associated with the given key as an os.FileMode.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) FileModeOrDefault(key string, def os.FileMode) (val os.FileMode, used bool) {
+	var err error
+	val, err = c.FileMode(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseFileMode(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out os.FileMode
+		err bool
+	}{
+		{"0640", 0640, false},
+		{"0o640", 0640, false},
+		{"640", 0640, false},
+		{"0", 0, false},
+		{"4755", 0755 | os.ModeSetuid, false},
+		{"2755", 0755 | os.ModeSetgid, false},
+		{"1777", 0777 | os.ModeSticky, false},
+		{"rw-r-----", 0640, false},
+		{"rwxr-xr-x", 0755, false},
+		{"---------", 0, false},
+		{"rwsr-xr-x", 0755 | os.ModeSetuid, false},
+		{"rwSr--r--", 0644 | os.ModeSetuid, false},
+		{"rwxr-sr-x", 0755 | os.ModeSetgid, false},
+		{"rwxrwxrwt", 0777 | os.ModeSticky, false},
+		{"rwxrwxrwT", 0776 | os.ModeSticky, false},
+		{"u=rw,g=r", 0640, false},
+		{"u=rwx,go=rx", 0755, false},
+		{"a=r,u+w", 0644, false},
+		{"=rwx,o-wx", 0774, false},
+		{"u=rwxs,go=rx", 0755 | os.ModeSetuid, false},
+		{"a=rwxt", 0777 | os.ModeSticky, false},
+		{"u=", 0, false},
+		{"10000", 0, true},
+		{"0o10000", 0, true},
+		{"0648", 0, true},
+		{"rw-r--r-x-", 0, true},
+		{"rwxrwxrws", 0, true},
+		{"wr-r-----", 0, true},
+		{"u=rwq", 0, true},
+		{"z=rw", 0, true},
+		{"urw", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			mode, err := ParseFileMode(test.in)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %s", mode)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("did not expect an error: %s", err)
+			}
+			if mode != test.out {
+				t.Errorf("expected %s but got %s", test.out, mode)
+			}
+		})
+	}
+}
+
+func TestConfig_FileMode(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	socket = 0660
+	log = u=rw,g=r
+	bad = 0999
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	if mode, err := cfg.FileMode("socket"); err != nil || mode != 0660 {
+		t.Errorf("expected %s but got %s (%v)", os.FileMode(0660), mode, err)
+	}
+	if mode, used := cfg.FileModeOrDefault("log", 0600); mode != 0640 || used {
+		t.Errorf("expected %s but got %s", os.FileMode(0640), mode)
+	}
+	if _, err := cfg.FileMode("bad"); err == nil {
+		t.Error("expected an error but did not get one")
+	}
+	if _, err := cfg.FileMode("missing"); err != ErrKeyNotFound {
+		t.Error("expected 'ErrKeyNotFound'")
+	}
+	if mode, used := cfg.FileModeOrDefault("bad", 0600); mode != 0600 || !used {
+		t.Errorf("expected default %s but got %s", os.FileMode(0600), mode)
+	}
+	if mode, err := Get[os.FileMode](cfg, "socket"); err != nil || mode != 0660 {
+		t.Errorf("expected Get to return %s but got %s (%v)", os.FileMode(0660), mode, err)
+	}
+}
@@ -0,0 +1,291 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrUnsupportedType returned when there is no way to parse a value into the requested type
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// getter returns the value associated with key parsed into a particular type.
+type getter func(c *Config, key string) (interface{}, error)
+
+var gettersMu sync.RWMutex
+
+// getters holds how to get each type supported by Get and GetOrDefault.
+var getters = map[reflect.Type]getter{
+	typeOf[string]():         func(c *Config, key string) (interface{}, error) { return c.String(key) },
+	typeOf[bool]():           func(c *Config, key string) (interface{}, error) { return c.Bool(key) },
+	typeOf[float32]():        func(c *Config, key string) (interface{}, error) { return c.Float32(key) },
+	typeOf[float64]():        func(c *Config, key string) (interface{}, error) { return c.Float64(key) },
+	typeOf[int]():            func(c *Config, key string) (interface{}, error) { return c.Int(key) },
+	typeOf[int8]():           func(c *Config, key string) (interface{}, error) { return c.Int8(key) },
+	typeOf[int16]():          func(c *Config, key string) (interface{}, error) { return c.Int16(key) },
+	typeOf[int32]():          func(c *Config, key string) (interface{}, error) { return c.Int32(key) },
+	typeOf[int64]():          func(c *Config, key string) (interface{}, error) { return c.Int64(key) },
+	typeOf[uint]():           func(c *Config, key string) (interface{}, error) { return c.Uint(key) },
+	typeOf[uint8]():          func(c *Config, key string) (interface{}, error) { return c.Uint8(key) },
+	typeOf[uint16]():         func(c *Config, key string) (interface{}, error) { return c.Uint16(key) },
+	typeOf[uint32]():         func(c *Config, key string) (interface{}, error) { return c.Uint32(key) },
+	typeOf[uint64]():         func(c *Config, key string) (interface{}, error) { return c.Uint64(key) },
+	typeOf[time.Duration]():  func(c *Config, key string) (interface{}, error) { return c.Duration(key) },
+	typeOf[*url.URL]():       func(c *Config, key string) (interface{}, error) { return c.URL(key) },
+	typeOf[net.IP]():         func(c *Config, key string) (interface{}, error) { return c.IP(key) },
+	typeOf[os.FileMode]():    func(c *Config, key string) (interface{}, error) { return c.FileMode(key) },
+	typeOf[time.Time]():      func(c *Config, key string) (interface{}, error) { return c.Time(key) },
+	typeOf[TimeOfDay]():      func(c *Config, key string) (interface{}, error) { return c.TimeOfDayValue(key) },
+	typeOf[Window]():         func(c *Config, key string) (interface{}, error) { return c.Window(key) },
+	typeOf[Schedule]():       func(c *Config, key string) (interface{}, error) { return c.Schedule(key) },
+	typeOf[Cron]():           func(c *Config, key string) (interface{}, error) { return c.Cron(key) },
+	typeOf[*time.Location](): func(c *Config, key string) (interface{}, error) { return c.Location(key) },
+}
+
+// builtins are the types with getter methods, whose parsers cannot be replaced.
+var builtins = func() map[reflect.Type]bool {
+	var m = make(map[reflect.Type]bool, len(getters))
+	for t := range getters {
+		m[t] = true
+	}
+	return m
+}()
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func getterOf[T any]() (getter, error) {
+	var t = typeOf[T]()
+	gettersMu.RLock()
+	get, ok := getters[t]
+	gettersMu.RUnlock()
+	if ok {
+		return get, nil
+	}
+	if get = textGetter(t); get != nil {
+		return get, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+}
+
+// typedGetterOf returns the getter for T with its result typed as a T.
+func typedGetterOf[T any]() (func(c *Config, key string) (T, error), error) {
+	get, err := getterOf[T]()
+	if err != nil {
+		return nil, err
+	}
+	return func(c *Config, key string) (val T, err error) {
+		v, err := get(c, key)
+		if err != nil {
+			return val, err
+		}
+		return v.(T), nil
+	}, nil
+}
+
+// mustTypedGetterOf is like typedGetterOf but panics if T is not supported.
+func mustTypedGetterOf[T any]() func(c *Config, key string) (T, error) {
+	get, err := typedGetterOf[T]()
+	if err != nil {
+		panic(err)
+	}
+	return get
+}
+
+// newType returns the Type with the given name for values parsed into a T.
+func newType[T any](name string) Type {
+	get, err := getterOf[T]()
+	if err != nil {
+		panic(err)
+	}
+	return Type{name, get}
+}
+
+// equalOf returns how values of type T are compared by OnChangeOf: with their
+// Equal method if they have one, by their text for URLs and time zones, and
+// with reflect.DeepEqual otherwise.
+func equalOf[T any]() func(a, b T) bool {
+	switch any(*new(T)).(type) {
+	case *url.URL:
+		return func(a, b T) bool { return equalURLs(any(a).(*url.URL), any(b).(*url.URL)) }
+	case *time.Location:
+		return func(a, b T) bool { return equalLocations(any(a).(*time.Location), any(b).(*time.Location)) }
+	case interface{ Equal(T) bool }:
+		return func(a, b T) bool { return any(a).(interface{ Equal(T) bool }).Equal(b) }
+	}
+	return func(a, b T) bool { return reflect.DeepEqual(a, b) }
+}
+
+var textUnmarshalerType = typeOf[encoding.TextUnmarshaler]()
+
+// textGetter returns a getter for types that implement encoding.TextUnmarshaler,
+// either directly or through a pointer, or nil if t does not.
+func textGetter(t reflect.Type) getter {
+	switch {
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return func(c *Config, key string) (interface{}, error) {
+			str, err := c.String(key)
+			if err != nil {
+				return nil, err
+			}
+			var p = reflect.New(t)
+			if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+				return nil, err
+			}
+			return p.Elem().Interface(), nil
+		}
+	case t.Kind() == reflect.Pointer && t.Implements(textUnmarshalerType):
+		return func(c *Config, key string) (interface{}, error) {
+			str, err := c.String(key)
+			if err != nil {
+				return nil, err
+			}
+			var p = reflect.New(t.Elem())
+			if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+				return nil, err
+			}
+			return p.Interface(), nil
+		}
+	}
+	return nil
+}
+
+// RegisterParser makes values of type T available to Get, GetOrDefault,
+// OptionalOf, ReaderGet, VarOf, OnChangeOf and TypeOf, using parse to turn the
+// text of a value into a T. Registering a parser again for the same type
+// replaces the earlier one.
+//
+// Types that implement encoding.TextUnmarshaler, directly or through a
+// pointer, are supported without registering a parser, but registering one
+// takes precedence. RegisterParser panics if T has a getter method, such as
+// int or time.Duration, so that every way of getting a T parses it the same.
+func RegisterParser[T any](parse func(s string) (T, error)) {
+	var t = typeOf[T]()
+	if builtins[t] {
+		panic(fmt.Errorf("cannot register a parser for %s: it has a getter method", t))
+	}
+	gettersMu.Lock()
+	defer gettersMu.Unlock()
+	getters[t] = func(c *Config, key string) (interface{}, error) {
+		str, err := c.String(key)
+		if err != nil {
+			return nil, err
+		}
+		return parse(str)
+	}
+}
+
+// Get returns the value associated with the given key parsed into a T, as the
+// getter method for T would, e.g. Get[int](c, key) is equivalent to c.Int(key).
+// T may be any type that has a getter method, including TimeOfDay, Window,
+// Schedule, Cron, time.Time (in RFC 3339) and *time.Location, any type
+// registered with RegisterParser, or any type that implements
+// encoding.TextUnmarshaler. File paths, dates and byte sizes are not distinct
+// types: Get[string] returns the value as written and Get[uint64] does not
+// accept units, so use Get[ByteSize] for values such as "1.5KiB".
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error wrapping ErrUnsupportedType is returned if T is not supported.
+func Get[T any](c *Config, key string) (val T, err error) {
+	get, err := typedGetterOf[T]()
+	if err != nil {
+		return val, err
+	}
+	return get(c, key)
+}
+
+// GetOrDefault returns the value associated with the given key parsed into a T,
+// as Get would.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func GetOrDefault[T any](c *Config, key string, def T) (val T, used bool) {
+	val, err := Get[T](c, key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// TypeOf returns the Type for values parsed into a T, as Get would, for use in
+// Schemas and Diffs.
+// It panics if T is not supported.
+func TypeOf[T any]() Type {
+	get, err := getterOf[T]()
+	if err != nil {
+		panic(err)
+	}
+	return Type{typeOf[T]().String(), get}
+}
+
+// OptionalOf returns the value associated with the given key parsed into a T,
+// as Get would.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a T, a *ValueError is returned.
+// An error wrapping ErrUnsupportedType is returned if T is not supported.
+func OptionalOf[T any](c *Config, key string, def T) (val T, err error) {
+	get, err := typedGetterOf[T]()
+	if err != nil {
+		return val, err
+	}
+	return optional(c, key, def, func(key string) (T, error) { return get(c, key) })
+}
+
+// ReaderGet returns the value associated with the given key parsed into a T,
+// as Get would, recording an error in r and returning the zero value if it fails.
+// An error wrapping ErrUnsupportedType is recorded if T is not supported.
+func ReaderGet[T any](r *Reader, key string) (val T) {
+	get, err := typedGetterOf[T]()
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return val
+	}
+	return collect(r, key, func(key string) (T, error) { return get(r.c, key) })
+}
+
+// VarOf binds the variable p to the given key.
+// p is set to the value GetOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+// It panics if T is not supported.
+func VarOf[T any](p *T, c *Config, key string, def T) {
+	mustTypedGetterOf[T]()
+	c.bind(key, func() { *p, _ = GetOrDefault(c, key, def) })
+}
+
+// OnChangeOf is like Watcher.OnChange but compares and reports the values
+// parsed into a T, as Get would. Values are compared with their Equal method
+// if they have one, e.g. time.Time.Equal, and with reflect.DeepEqual otherwise.
+// Missing and unparsable values are reported as the zero value of T, but
+// additions and deletions are reported even when the other value is also the zero value.
+// It panics if T is not supported.
+func OnChangeOf[T any](w *Watcher, section, key string, fn func(old, new T)) {
+	onTypedChange(w, section, key, mustTypedGetterOf[T](), equalOf[T](), fn)
+}
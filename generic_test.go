@@ -0,0 +1,350 @@
+package config
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	str = hello
+	bool = true
+	float32 = 1.5
+	float64 = 2.5
+	int = -1
+	int32 = -2
+	int64 = -3
+	uint = 1
+	uint32 = 2
+	uint64 = 3
+	duration = 3m20s
+	url = https://example.com
+	ip = 127.0.0.1
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var check = func(name string, got, exp interface{}, err error) {
+		t.Run(name, func(t *testing.T) {
+			if err != nil {
+				t.Errorf("did not expect an error: %s", err)
+			}
+			if got != exp {
+				t.Errorf("expected %v but got %v", exp, got)
+			}
+		})
+	}
+	var (
+		s, e1   = Get[string](cfg, "str")
+		b, e2   = Get[bool](cfg, "bool")
+		f32, e3 = Get[float32](cfg, "float32")
+		f64, e4 = Get[float64](cfg, "float64")
+		i, e5   = Get[int](cfg, "int")
+		i32, e6 = Get[int32](cfg, "int32")
+		i64, e7 = Get[int64](cfg, "int64")
+		u, e8   = Get[uint](cfg, "uint")
+		u32, e9 = Get[uint32](cfg, "uint32")
+		u64, ea = Get[uint64](cfg, "uint64")
+		d, eb   = Get[time.Duration](cfg, "duration")
+	)
+	check("string", s, "hello", e1)
+	check("bool", b, true, e2)
+	check("float32", f32, float32(1.5), e3)
+	check("float64", f64, 2.5, e4)
+	check("int", i, -1, e5)
+	check("int32", i32, int32(-2), e6)
+	check("int64", i64, int64(-3), e7)
+	check("uint", u, uint(1), e8)
+	check("uint32", u32, uint32(2), e9)
+	check("uint64", u64, uint64(3), ea)
+	check("duration", d, 200*time.Second, eb)
+
+	t.Run("url", func(t *testing.T) {
+		u, err := Get[*url.URL](cfg, "url")
+		if err != nil || u.Host != "example.com" {
+			t.Errorf("expected host 'example.com' but got %v (%v)", u, err)
+		}
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		ip, err := Get[net.IP](cfg, "ip")
+		if err != nil || !ip.Equal(net.IPv4(127, 0, 0, 1)) {
+			t.Errorf("expected 127.0.0.1 but got %v (%v)", ip, err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := Get[int](cfg, "missing"); err != ErrKeyNotFound {
+			t.Errorf("expected ErrKeyNotFound but got %v", err)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := Get[complex128](cfg, "int"); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("expected ErrUnsupportedType but got %v", err)
+		}
+	})
+}
+
+func TestGetOrDefault(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	port = 54x2
+	every = 1m
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+	cfg.Track()
+	var defaulted []string
+	cfg.OnDefault(func(key, raw string, err error) {
+		defaulted = append(defaulted, key+"="+raw)
+	})
+
+	every, used := GetOrDefault(cfg, "every", time.Second)
+	if every != time.Minute || used {
+		t.Errorf("expected %s but got %s (used=%t)", time.Minute, every, used)
+	}
+	port, used := GetOrDefault(cfg, "port", 8080)
+	if port != 8080 || !used {
+		t.Errorf("expected default 8080 but got %d (used=%t)", port, used)
+	}
+	if len(defaulted) != 1 || defaulted[0] != "port=54x2" {
+		t.Errorf("expected the OnDefault callback for 'port' but got %v", defaulted)
+	}
+	if r := cfg.Report(); len(r.Read) != 2 || len(r.Defaulted) != 1 {
+		t.Errorf("expected both reads and the default to be tracked but got %+v", r)
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	var s = NewSchema()
+	s.Section("").Key("every", TypeOf[time.Duration]()).Max(time.Hour)
+	var cfg = FromMap(map[string]string{"every": "2h"})
+	if err := s.Validate(map[string]*Config{"": cfg}).Err(); !errors.Is(err, ErrConstraint) {
+		t.Errorf("expected ErrConstraint but got %v", err)
+	}
+	if TypeOf[time.Duration]().String() != "time.Duration" {
+		t.Errorf("expected 'time.Duration' but got %s", TypeOf[time.Duration]())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected TypeOf to panic for an unsupported type")
+		}
+	}()
+	TypeOf[complex128]()
+}
+
+type tier int
+
+const (
+	tierFree tier = iota
+	tierPro
+)
+
+type region struct {
+	code string
+}
+
+func (r *region) UnmarshalText(text []byte) error {
+	if len(text) != 2 {
+		return errors.New("region code must be 2 letters")
+	}
+	r.code = strings.ToUpper(string(text))
+	return nil
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(func(s string) (tier, error) {
+		switch s {
+		case "free":
+			return tierFree, nil
+		case "pro":
+			return tierPro, nil
+		}
+		return 0, errors.New("unknown tier")
+	})
+
+	cfgs, err := Read(strings.NewReader(`
+	tier = pro
+	bad_tier = gold
+	region = us
+	bad_region = usa
+	addr = 10.0.0.1
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	t.Run("parser", func(t *testing.T) {
+		if val, err := Get[tier](cfg, "tier"); err != nil || val != tierPro {
+			t.Errorf("expected tierPro but got %v (%v)", val, err)
+		}
+		if val, used := GetOrDefault(cfg, "bad_tier", tierFree); val != tierFree || !used {
+			t.Errorf("expected default tierFree but got %v", val)
+		}
+	})
+
+	t.Run("TextUnmarshaler", func(t *testing.T) {
+		if val, err := Get[region](cfg, "region"); err != nil || val.code != "US" {
+			t.Errorf("expected US but got %v (%v)", val, err)
+		}
+		if val, err := Get[*region](cfg, "region"); err != nil || val.code != "US" {
+			t.Errorf("expected US but got %v (%v)", val, err)
+		}
+		if _, err := Get[region](cfg, "bad_region"); err == nil {
+			t.Error("expected an error but did not get one")
+		}
+		if _, err := Get[region](cfg, "missing"); err != ErrKeyNotFound {
+			t.Errorf("expected ErrKeyNotFound but got %v", err)
+		}
+		if val, err := Get[netip.Addr](cfg, "addr"); err != nil || val != netip.MustParseAddr("10.0.0.1") {
+			t.Errorf("expected 10.0.0.1 but got %v (%v)", val, err)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		var s = NewSchema()
+		s.Section("").Key("bad_tier", TypeOf[tier]())
+		s.Section("").Key("bad_region", TypeOf[region]())
+		var ps = s.Validate(cfgs)
+		var errs = 0
+		for _, p := range ps {
+			if p.Severity == SeverityError {
+				errs++
+			}
+		}
+		if errs != 2 {
+			t.Errorf("expected 2 errors but got %v", ps)
+		}
+	})
+}
+
+func TestRegisterParser_builtin(t *testing.T) {
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected RegisterParser to panic for int")
+			}
+		}()
+		RegisterParser(func(s string) (int, error) { return 42, nil })
+	}()
+
+	cfg := FromMap(map[string]string{"port": "8080"})
+	var port int
+	IntVar(&port, cfg, "port", 1)
+	var exp, _ = cfg.IntOrDefault("port", 1)
+	if val, err := cfg.OptionalInt("port", 1); err != nil || val != exp {
+		t.Errorf("expected OptionalInt to return %d but got %d (%v)", exp, val, err)
+	}
+	if val := cfg.Reader().Int("port"); val != exp {
+		t.Errorf("expected Reader.Int to return %d but got %d", exp, val)
+	}
+	if val, err := Get[int](cfg, "port"); err != nil || val != exp {
+		t.Errorf("expected Get to return %d but got %d (%v)", exp, val, err)
+	}
+	if port != exp {
+		t.Errorf("expected IntVar to set %d but got %d", exp, port)
+	}
+}
+
+func TestGenericWrappers(t *testing.T) {
+	RegisterParser(func(s string) (tier, error) {
+		switch s {
+		case "free":
+			return tierFree, nil
+		case "pro":
+			return tierPro, nil
+		}
+		return 0, errors.New("unknown tier")
+	})
+
+	cfgs, err := Read(strings.NewReader(`
+	tier = pro
+	bad_tier = gold
+	region = us
+	bad_region = usa
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	t.Run("OptionalOf", func(t *testing.T) {
+		if val, err := OptionalOf(cfg, "tier", tierFree); err != nil || val != tierPro {
+			t.Errorf("expected tierPro but got %v (%v)", val, err)
+		}
+		if val, err := OptionalOf(cfg, "missing", tierFree); err != nil || val != tierFree {
+			t.Errorf("expected the default but got %v (%v)", val, err)
+		}
+		var verr *ValueError
+		if _, err := OptionalOf(cfg, "bad_region", region{}); !errors.As(err, &verr) || verr.Value != "usa" {
+			t.Errorf("expected a *ValueError but got %v", err)
+		}
+		if _, err := OptionalOf(cfg, "tier", struct{}{}); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("expected ErrUnsupportedType but got %v", err)
+		}
+	})
+
+	t.Run("ReaderGet", func(t *testing.T) {
+		var r = cfg.Reader()
+		if val := ReaderGet[region](r, "region"); val.code != "US" {
+			t.Errorf("expected US but got %v", val)
+		}
+		ReaderGet[tier](r, "bad_tier")
+		ReaderGet[tier](r, "missing")
+		ReaderGet[struct{}](r, "tier")
+		var err = r.Err()
+		if !errors.Is(err, ErrKeyNotFound) || !errors.Is(err, ErrUnsupportedType) || !strings.Contains(err.Error(), "gold") {
+			t.Errorf("expected every error to be collected but got %v", err)
+		}
+	})
+
+	t.Run("VarOf", func(t *testing.T) {
+		var c = New()
+		c.Set("tier", "free")
+		var val tier
+		VarOf(&val, c, "tier", tierFree)
+		if val != tierFree {
+			t.Errorf("expected tierFree but got %v", val)
+		}
+		c.Set("tier", "pro")
+		if val != tierPro {
+			t.Errorf("expected the variable to follow the key but got %v", val)
+		}
+		c.Set("tier", "gold")
+		if val != tierFree {
+			t.Errorf("expected the default but got %v", val)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic for an unsupported type")
+			}
+		}()
+		var bad struct{}
+		VarOf(&bad, c, "tier", struct{}{})
+	})
+}
+
+func TestGet_timeAndSizeTypes(t *testing.T) {
+	cfg := FromMap(map[string]string{"at": "02:30 UTC", "size": "1.5KiB"})
+	if val, err := Get[TimeOfDay](cfg, "at"); err != nil || val.String() != "02:30 UTC" {
+		t.Errorf("expected 02:30 UTC but got %s (%v)", val, err)
+	}
+	if val, err := Get[ByteSize](cfg, "size"); err != nil || val != 1536 {
+		t.Errorf("expected 1536 but got %d (%v)", val, err)
+	}
+	if _, err := Get[uint64](cfg, "size"); err == nil {
+		t.Error("expected Get[uint64] not to accept units")
+	}
+}
@@ -0,0 +1,275 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// optional returns the value of key as parsed by get, or def if the key does
+// not exist. If the key exists but get fails, a *ValueError is returned.
+func optional[T any](c *Config, key string, def T, get func(key string) (T, error)) (val T, err error) {
+	val, err = get(key)
+	if err == ErrKeyNotFound {
+		c.defaulted(key, err)
+		return def, nil
+	}
+	if err != nil {
+		var raw, _ = c.get(key)
+		var zero T
+		return zero, &ValueError{Section: c.Name(), Key: key, Value: raw, Err: err}
+	}
+	return val, nil
+}
+
+// OptionalString returns the value associated with the given key as a string.
+// If the key does not exist, the default value "def" is returned.
+func (c *Config) OptionalString(key string, def string) (val string, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalBool returns the value associated with the given key as a bool.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a bool, a *ValueError is returned.
+func (c *Config) OptionalBool(key string, def bool) (val bool, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalFloat32 returns the value associated with the given key as a float32.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a float32, a *ValueError is returned.
+func (c *Config) OptionalFloat32(key string, def float32) (val float32, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalFloat64 returns the value associated with the given key as a float64.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a float64, a *ValueError is returned.
+func (c *Config) OptionalFloat64(key string, def float64) (val float64, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalInt returns the value associated with the given key as an int.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an int, a *ValueError is returned.
+func (c *Config) OptionalInt(key string, def int) (val int, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalInt8 returns the value associated with the given key as an int8.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an int8, a *ValueError is returned.
+func (c *Config) OptionalInt8(key string, def int8) (val int8, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalInt16 returns the value associated with the given key as an int16.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an int16, a *ValueError is returned.
+func (c *Config) OptionalInt16(key string, def int16) (val int16, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalInt32 returns the value associated with the given key as an int32.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an int32, a *ValueError is returned.
+func (c *Config) OptionalInt32(key string, def int32) (val int32, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalInt64 returns the value associated with the given key as an int64.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an int64, a *ValueError is returned.
+func (c *Config) OptionalInt64(key string, def int64) (val int64, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalUint returns the value associated with the given key as a uint.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a uint, a *ValueError is returned.
+func (c *Config) OptionalUint(key string, def uint) (val uint, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalUint8 returns the value associated with the given key as a uint8.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a uint8, a *ValueError is returned.
+func (c *Config) OptionalUint8(key string, def uint8) (val uint8, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalUint16 returns the value associated with the given key as a uint16.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a uint16, a *ValueError is returned.
+func (c *Config) OptionalUint16(key string, def uint16) (val uint16, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalUint32 returns the value associated with the given key as a uint32.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a uint32, a *ValueError is returned.
+func (c *Config) OptionalUint32(key string, def uint32) (val uint32, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalUint64 returns the value associated with the given key as a uint64.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a uint64, a *ValueError is returned.
+func (c *Config) OptionalUint64(key string, def uint64) (val uint64, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalDuration returns the value associated with the given key as a time.Duration.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a time.Duration, a *ValueError is returned.
+func (c *Config) OptionalDuration(key string, def time.Duration) (val time.Duration, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalURL returns the value associated with the given key as a *url.URL.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a *url.URL, a *ValueError is returned.
+func (c *Config) OptionalURL(key string, def *url.URL) (val *url.URL, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalFilePath returns the value associated with the given key as a string that
+// has been interpreted as a file path and cleaned.
+// If the key does not exist, the default value "def" is returned.
+func (c *Config) OptionalFilePath(key string, def string) (val string, err error) {
+	return optional(c, key, def, c.FilePath)
+}
+
+// OptionalExistingFile returns the value associated with the given key as the
+// path of an existing file, as ExistingFile would.
+// If the key does not exist, the default value "def" is returned.
+// If the file does not exist, a *ValueError is returned.
+func (c *Config) OptionalExistingFile(key string, def string) (val string, err error) {
+	return optional(c, key, def, c.ExistingFile)
+}
+
+// OptionalExistingDir returns the value associated with the given key as the
+// path of an existing directory, as ExistingDir would.
+// If the key does not exist, the default value "def" is returned.
+// If the directory does not exist, a *ValueError is returned.
+func (c *Config) OptionalExistingDir(key string, def string) (val string, err error) {
+	return optional(c, key, def, c.ExistingDir)
+}
+
+// OptionalWritableDir returns the value associated with the given key as the
+// path of a writable directory, as WritableDir would.
+// If the key does not exist, the default value "def" is returned.
+// If the directory is not writable, a *ValueError is returned.
+func (c *Config) OptionalWritableDir(key string, def string) (val string, err error) {
+	return optional(c, key, def, c.WritableDir)
+}
+
+// OptionalTimeOfDay returns the value associated with the given key as a time of day
+// in HH24:MM format.
+// If the key does not exist, the default values "defHour" and "defMinute" are returned.
+// If the value cannot be parsed into valid hour and minute components, a *ValueError is returned.
+func (c *Config) OptionalTimeOfDay(key string, defHour, defMinute int) (hour, minute int, err error) {
+	var hm [2]int
+	hm, err = optional(c, key, [2]int{defHour, defMinute}, func(key string) ([2]int, error) {
+		hour, minute, err := c.TimeOfDay(key)
+		return [2]int{hour, minute}, err
+	})
+	if err != nil {
+		return -1, -1, err
+	}
+	return hm[0], hm[1], nil
+}
+
+// OptionalIP returns the value associated with the given key as a net.IP.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a net.IP, a *ValueError is returned.
+func (c *Config) OptionalIP(key string, def net.IP) (val net.IP, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalBytes returns the value associated with the given key as a number of bytes.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a number of bytes, a *ValueError is returned.
+func (c *Config) OptionalBytes(key string, def uint64) (val uint64, err error) {
+	return optional(c, key, def, c.Bytes)
+}
+
+// OptionalTimeOfDayValue returns the value associated with the given key as a TimeOfDay.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a TimeOfDay, a *ValueError is returned.
+func (c *Config) OptionalTimeOfDayValue(key string, def TimeOfDay) (val TimeOfDay, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalWindow returns the value associated with the given key as a Window.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a Window, a *ValueError is returned.
+func (c *Config) OptionalWindow(key string, def Window) (val Window, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalSchedule returns the value associated with the given key as a Schedule.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a Schedule, a *ValueError is returned.
+func (c *Config) OptionalSchedule(key string, def Schedule) (val Schedule, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalCron returns the value associated with the given key as a Cron.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a Cron, a *ValueError is returned.
+func (c *Config) OptionalCron(key string, def Cron) (val Cron, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalTime returns the value associated with the given key as a time.Time,
+// parsed as Time would.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a time.Time, a *ValueError is returned.
+func (c *Config) OptionalTime(key string, def time.Time, layout ...string) (val time.Time, err error) {
+	return optional(c, key, def, func(key string) (time.Time, error) { return c.Time(key, layout...) })
+}
+
+// OptionalDate returns the value associated with the given key as a date, as Date would.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into a date, a *ValueError is returned.
+func (c *Config) OptionalDate(key string, def time.Time) (val time.Time, err error) {
+	return optional(c, key, def, c.Date)
+}
+
+// OptionalLocation returns the value associated with the given key as a *time.Location.
+// If the key does not exist, the default value "def" is returned.
+// If the time zone is unknown, a *ValueError is returned.
+func (c *Config) OptionalLocation(key string, def *time.Location) (val *time.Location, err error) {
+	return OptionalOf(c, key, def)
+}
+
+// OptionalFileMode returns the value associated with the given key as an os.FileMode.
+// If the key does not exist, the default value "def" is returned.
+// If the value cannot be parsed into an os.FileMode, a *ValueError is returned.
+func (c *Config) OptionalFileMode(key string, def os.FileMode) (val os.FileMode, err error) {
+	return OptionalOf(c, key, def)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_OptionalInt(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+database:
+	port = 54x2
+	pool = 10
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cs.Config("database")
+
+	val, err := cfg.OptionalInt("pool", 5)
+	t.Run("present", func(t *testing.T) {
+		if err != nil || val != 10 {
+			t.Errorf("expected 10 but got %d (%v)", val, err)
+		}
+	})
+
+	val, err = cfg.OptionalInt("timeout", 30)
+	t.Run("missing", func(t *testing.T) {
+		if err != nil || val != 30 {
+			t.Errorf("expected default 30 but got %d (%v)", val, err)
+		}
+	})
+
+	_, err = cfg.OptionalInt("port", 5432)
+	t.Run("invalid", func(t *testing.T) {
+		var ve *ValueError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected a *ValueError but got %v", err)
+		}
+		if ve.Section != "database" || ve.Key != "port" || ve.Value != "54x2" {
+			t.Errorf("expected the error to describe 'port' but got %+v", ve)
+		}
+		var exp = `key "port" in section "database": invalid value "54x2": strconv.ParseInt: parsing "54x2": invalid syntax`
+		if err.Error() != exp {
+			t.Errorf("expected:\n%s\nbut got:\n%s", exp, err)
+		}
+	})
+}
+
+func TestConfig_Optional(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	bad = zulu
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var defaulted []string
+	cfg.OnDefault(func(key, raw string, err error) {
+		defaulted = append(defaulted, key)
+	})
+
+	var getters = map[string]func(key string) error{
+		"Bool":      func(key string) error { _, err := cfg.OptionalBool(key, false); return err },
+		"Float32":   func(key string) error { _, err := cfg.OptionalFloat32(key, 0); return err },
+		"Float64":   func(key string) error { _, err := cfg.OptionalFloat64(key, 0); return err },
+		"Int":       func(key string) error { _, err := cfg.OptionalInt(key, 0); return err },
+		"Int32":     func(key string) error { _, err := cfg.OptionalInt32(key, 0); return err },
+		"Int64":     func(key string) error { _, err := cfg.OptionalInt64(key, 0); return err },
+		"Uint":      func(key string) error { _, err := cfg.OptionalUint(key, 0); return err },
+		"Uint32":    func(key string) error { _, err := cfg.OptionalUint32(key, 0); return err },
+		"Uint64":    func(key string) error { _, err := cfg.OptionalUint64(key, 0); return err },
+		"Duration":  func(key string) error { _, err := cfg.OptionalDuration(key, 0); return err },
+		"TimeOfDay": func(key string) error { _, _, err := cfg.OptionalTimeOfDay(key, 0, 0); return err },
+		"IP":        func(key string) error { _, err := cfg.OptionalIP(key, nil); return err },
+	}
+	for name, get := range getters {
+		t.Run(name, func(t *testing.T) {
+			defaulted = nil
+			var ve *ValueError
+			if err := get("bad"); !errors.As(err, &ve) {
+				t.Errorf("expected a *ValueError but got %v", err)
+			}
+			if err := get("missing"); err != nil {
+				t.Errorf("did not expect an error: %s", err)
+			}
+			if len(defaulted) != 1 || defaulted[0] != "missing" {
+				t.Errorf("expected only 'missing' to be defaulted but got %v", defaulted)
+			}
+		})
+	}
+
+	d, err := cfg.OptionalDuration("every", time.Minute)
+	if err != nil || d != time.Minute {
+		t.Errorf("expected default %s but got %s (%v)", time.Minute, d, err)
+	}
+	ip, err := cfg.OptionalIP("ip", net.IPv6loopback)
+	if err != nil || !ip.Equal(net.IPv6loopback) {
+		t.Errorf("expected default %s but got %s (%v)", net.IPv6loopback, ip, err)
+	}
+	hour, minute, err := cfg.OptionalTimeOfDay("at", 2, 30)
+	if err != nil || hour != 2 || minute != 30 {
+		t.Errorf("expected default 2:30 but got %d:%d (%v)", hour, minute, err)
+	}
+}
@@ -0,0 +1,192 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathOptions control how FilePath and related getters interpret file paths.
+// Options are combined with |.
+type PathOptions int
+
+const (
+	// ResolveRelative resolves relative paths against the directory of the file
+	// the key was read from, or against the directory set with SetBaseDir for
+	// keys that were not read from a file.
+	ResolveRelative PathOptions = 1 << iota
+	// ExpandHome replaces a leading ~ with the current user's home directory.
+	ExpandHome
+	// ExpandEnv replaces $VAR and ${VAR} with the values of environment variables.
+	ExpandEnv
+)
+
+// SetPathOptions sets how FilePath and related getters interpret file paths.
+// By default, paths are only cleaned.
+func (c *Config) SetPathOptions(opts PathOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pathOpts = opts
+}
+
+// SetBaseDir sets the directory relative paths are resolved against when
+// ResolveRelative is set, for keys that were not read from a file with
+// ReadFile or ReadFiles.
+func (c *Config) SetBaseDir(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseDir = dir
+}
+
+// resolvePath interprets the value of key as a file path according to the path options.
+func (c *Config) resolvePath(key, str string) (string, error) {
+	c.mu.RLock()
+	var opts = c.pathOpts
+	var dir, ok = c.dirs[key]
+	if !ok {
+		dir = c.baseDir
+	}
+	c.mu.RUnlock()
+
+	if opts&ExpandEnv != 0 {
+		str = os.ExpandEnv(str)
+	}
+	if opts&ExpandHome != 0 && (str == "~" || strings.HasPrefix(str, "~/") || strings.HasPrefix(str, "~"+string(filepath.Separator))) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		str = home + str[1:]
+	}
+	if opts&ResolveRelative != 0 && dir != "" && !filepath.IsAbs(str) {
+		str = filepath.Join(dir, str)
+	}
+	return filepath.Clean(str), nil
+}
+
+// ExistingFile returns the value associated with the given key as a file path,
+// as FilePath would, and checks that it names an existing file that is not a directory.
+// If the key does not exist, ErrKeyNotFound is returned.
+func (c *Config) ExistingFile(key string) (val string, err error) {
+	val, err = c.FilePath(key)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(val)
+	if err != nil {
+		return "", statError("file", val, err)
+	}
+	if fi.IsDir() {
+		return "", fmt.Errorf("%s is a directory, not a file", val)
+	}
+	return val, nil
+}
+
+// ExistingFileOrDefault returns the value associated with the given key as the
+// path of an existing file, as ExistingFile would.
+// If the key does not exist or the file does not, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) ExistingFileOrDefault(key string, def string) (val string, used bool) {
+	var err error
+	val, err = c.ExistingFile(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// ExistingDir returns the value associated with the given key as a file path,
+// as FilePath would, and checks that it names an existing directory.
+// If the key does not exist, ErrKeyNotFound is returned.
+func (c *Config) ExistingDir(key string) (val string, err error) {
+	val, err = c.FilePath(key)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(val)
+	if err != nil {
+		return "", statError("directory", val, err)
+	}
+	if !fi.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", val)
+	}
+	return val, nil
+}
+
+// ExistingDirOrDefault returns the value associated with the given key as the
+// path of an existing directory, as ExistingDir would.
+// If the key does not exist or the directory does not, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) ExistingDirOrDefault(key string, def string) (val string, used bool) {
+	var err error
+	val, err = c.ExistingDir(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// WritableDir returns the value associated with the given key as a file path,
+// as FilePath would, and checks that it names an existing directory in which
+// files can be created, by creating and removing a temporary file.
+// If the key does not exist, ErrKeyNotFound is returned.
+func (c *Config) WritableDir(key string) (val string, err error) {
+	val, err = c.ExistingDir(key)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(val, ".config-write-check-*")
+	if err != nil {
+		return "", fmt.Errorf("directory %s is not writable: %w", val, err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return val, nil
+}
+
+// WritableDirOrDefault returns the value associated with the given key as the
+// path of a writable directory, as WritableDir would.
+// If the key does not exist or the directory is not writable, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) WritableDirOrDefault(key string, def string) (val string, used bool) {
+	var err error
+	val, err = c.WritableDir(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+func statError(kind, path string, err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s %s does not exist: %w", kind, path, os.ErrNotExist)
+	}
+	return fmt.Errorf("cannot access %s %s: %w", kind, path, err)
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_FilePath_Options(t *testing.T) {
+	t.Run("default options only clean", func(t *testing.T) {
+		t.Setenv("CONFIG_TEST_DIR", "/tmp")
+		cfg, err := Read(strings.NewReader("a=./x/../y\nb=$CONFIG_TEST_DIR/z\nc=~/w\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var tests = map[string]string{"a": "y", "b": "$CONFIG_TEST_DIR/z", "c": "~/w"}
+		for key, exp := range tests {
+			if val, err := cfg[""].FilePath(key); err != nil || val != exp {
+				t.Errorf("%s: expected %q, got %q (%v)", key, exp, val, err)
+			}
+		}
+	})
+
+	t.Run("expand env", func(t *testing.T) {
+		t.Setenv("CONFIG_TEST_DIR", "/srv/data")
+		cfg, _ := Read(strings.NewReader("a=$CONFIG_TEST_DIR/z\nb=${CONFIG_TEST_DIR}/../y\n"))
+		cfg[""].SetPathOptions(ExpandEnv)
+		if val, _ := cfg[""].FilePath("a"); val != "/srv/data/z" {
+			t.Errorf("expected /srv/data/z, got %q", val)
+		}
+		if val, _ := cfg[""].FilePath("b"); val != "/srv/y" {
+			t.Errorf("expected /srv/y, got %q", val)
+		}
+	})
+
+	t.Run("expand home", func(t *testing.T) {
+		var home = t.TempDir()
+		t.Setenv("HOME", home)
+		cfg, _ := Read(strings.NewReader("a=~/logs\nb=~\nc=~user/logs\n"))
+		cfg[""].SetPathOptions(ExpandHome)
+		if val, _ := cfg[""].FilePath("a"); val != filepath.Join(home, "logs") {
+			t.Errorf("expected %q, got %q", filepath.Join(home, "logs"), val)
+		}
+		if val, _ := cfg[""].FilePath("b"); val != home {
+			t.Errorf("expected %q, got %q", home, val)
+		}
+		if val, _ := cfg[""].FilePath("c"); val != "~user/logs" {
+			t.Errorf("expected ~user/logs, got %q", val)
+		}
+	})
+
+	t.Run("relative to file", func(t *testing.T) {
+		var dir = t.TempDir()
+		var sub = filepath.Join(dir, "sub")
+		if err := os.Mkdir(sub, 0o755); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		writeFile(t, filepath.Join(dir, "a.conf"), "log=logs/a.log\nabs=/var/a\n")
+		writeFile(t, filepath.Join(sub, "b.conf"), "data=../data\n")
+
+		cfgs, err := ReadFiles(filepath.Join(dir, "a.conf"), filepath.Join(sub, "b.conf"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		cfgs[""].SetPathOptions(ResolveRelative)
+		if val, _ := cfgs[""].FilePath("log"); val != filepath.Join(dir, "logs", "a.log") {
+			t.Errorf("expected %q, got %q", filepath.Join(dir, "logs", "a.log"), val)
+		}
+		if val, _ := cfgs[""].FilePath("abs"); val != "/var/a" {
+			t.Errorf("expected /var/a, got %q", val)
+		}
+		if val, _ := cfgs[""].FilePath("data"); val != filepath.Join(dir, "data") {
+			t.Errorf("expected %q, got %q", filepath.Join(dir, "data"), val)
+		}
+
+		// a value set in code is not relative to any file
+		cfgs[""].Set("log", "other.log")
+		if val, _ := cfgs[""].FilePath("log"); val != "other.log" {
+			t.Errorf("expected other.log, got %q", val)
+		}
+		cfgs[""].SetBaseDir("/etc/app")
+		if val, _ := cfgs[""].FilePath("log"); val != "/etc/app/other.log" {
+			t.Errorf("expected /etc/app/other.log, got %q", val)
+		}
+	})
+
+	t.Run("configs setters", func(t *testing.T) {
+		var cs = NewConfigs()
+		cs.Section("").Set("a", "x")
+		cs.Section("s").Set("b", "~/y")
+		cs.SetBaseDir("/base")
+		cs.SetPathOptions(ResolveRelative)
+		if val, _ := cs.Config("").FilePath("a"); val != "/base/x" {
+			t.Errorf("expected /base/x, got %q", val)
+		}
+		if val, _ := cs.Config("s").FilePath("b"); val != "/base/~/y" {
+			t.Errorf("expected /base/~/y, got %q", val)
+		}
+	})
+
+	t.Run("clone keeps options", func(t *testing.T) {
+		var cfg = New()
+		cfg.Set("a", "x")
+		cfg.SetBaseDir("/base")
+		cfg.SetPathOptions(ResolveRelative)
+		if val, _ := cfg.Clone().FilePath("a"); val != "/base/x" {
+			t.Errorf("expected /base/x, got %q", val)
+		}
+	})
+}
+
+func TestConfig_ExistingFile(t *testing.T) {
+	var dir = t.TempDir()
+	writeFile(t, filepath.Join(dir, "f"), "")
+	var cfg = New()
+	cfg.Set("file", filepath.Join(dir, "f"))
+	cfg.Set("dir", dir)
+	cfg.Set("missing", filepath.Join(dir, "nope"))
+
+	if val, err := cfg.ExistingFile("file"); err != nil || val != filepath.Join(dir, "f") {
+		t.Errorf("expected %q, got %q (%v)", filepath.Join(dir, "f"), val, err)
+	}
+	if _, err := cfg.ExistingFile("dir"); err == nil || !strings.Contains(err.Error(), "is a directory") {
+		t.Errorf("expected a directory error, got %v", err)
+	}
+	if _, err := cfg.ExistingFile("missing"); !errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+	if _, err := cfg.ExistingFile("absent"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	if val, used := cfg.ExistingFileOrDefault("missing", "def"); !used || val != "def" {
+		t.Errorf("expected the default, got %q", val)
+	}
+	if _, err := cfg.OptionalExistingFile("missing", "def"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if val, err := cfg.OptionalExistingFile("absent", "def"); err != nil || val != "def" {
+		t.Errorf("expected the default, got %q (%v)", val, err)
+	}
+}
+
+func TestConfig_ExistingDir(t *testing.T) {
+	var dir = t.TempDir()
+	writeFile(t, filepath.Join(dir, "f"), "")
+	var cfg = New()
+	cfg.Set("file", filepath.Join(dir, "f"))
+	cfg.Set("dir", dir)
+	cfg.Set("missing", filepath.Join(dir, "nope"))
+
+	if val, err := cfg.ExistingDir("dir"); err != nil || val != dir {
+		t.Errorf("expected %q, got %q (%v)", dir, val, err)
+	}
+	if _, err := cfg.ExistingDir("file"); err == nil || !strings.Contains(err.Error(), "is not a directory") {
+		t.Errorf("expected a not a directory error, got %v", err)
+	}
+	if _, err := cfg.ExistingDir("missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+	if val, used := cfg.ExistingDirOrDefault("file", "def"); !used || val != "def" {
+		t.Errorf("expected the default, got %q", val)
+	}
+}
+
+func TestConfig_WritableDir(t *testing.T) {
+	var dir = t.TempDir()
+	var cfg = New()
+	cfg.Set("dir", dir)
+	cfg.Set("missing", filepath.Join(dir, "nope"))
+
+	if val, err := cfg.WritableDir("dir"); err != nil || val != dir {
+		t.Errorf("expected %q, got %q (%v)", dir, val, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected the check to leave no files behind, got %d", len(entries))
+	}
+	if val, used := cfg.WritableDirOrDefault("missing", "def"); !used || val != "def" {
+		t.Errorf("expected the default, got %q", val)
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+	var ro = filepath.Join(dir, "ro")
+	if err := os.Mkdir(ro, 0o555); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg.Set("ro", ro)
+	if _, err := cfg.WritableDir("ro"); err == nil || !strings.Contains(err.Error(), "not writable") {
+		t.Errorf("expected a not writable error, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Reader reads many values from a Config, collecting every missing or invalid
+// key instead of stopping at the first one.
+//
+//	r := cfg.Reader()
+//	port := r.Int("port")
+//	ttl := r.Duration("ttl")
+//	if err := r.Err(); err != nil {
+//		return err
+//	}
+type Reader struct {
+	c    *Config
+	errs []error
+}
+
+// Reader returns a Reader for the configuration.
+func (c *Config) Reader() *Reader {
+	return &Reader{c: c}
+}
+
+// Err returns every error recorded by the Reader joined into one, or nil if
+// there were none. Missing keys are reported as *KeyError wrapping
+// ErrKeyNotFound and invalid values as *ValueError.
+func (r *Reader) Err() error {
+	return errors.Join(r.errs...)
+}
+
+// collect returns the value of key as parsed by get, recording an error and
+// returning the zero value if it fails.
+func collect[T any](r *Reader, key string, get func(key string) (T, error)) T {
+	val, err := get(key)
+	if err == ErrKeyNotFound {
+		r.errs = append(r.errs, &KeyError{Section: r.c.Name(), Key: key, Err: err})
+	} else if err != nil {
+		var raw, _ = r.c.get(key)
+		r.errs = append(r.errs, &ValueError{Section: r.c.Name(), Key: key, Value: raw, Err: err})
+	}
+	if err != nil {
+		var zero T
+		return zero
+	}
+	return val
+}
+
+// String returns the value associated with the given key as a string.
+func (r *Reader) String(key string) string {
+	return ReaderGet[string](r, key)
+}
+
+// Bool returns the value associated with the given key as a bool.
+func (r *Reader) Bool(key string) bool {
+	return ReaderGet[bool](r, key)
+}
+
+// Float32 returns the value associated with the given key as a float32.
+func (r *Reader) Float32(key string) float32 {
+	return ReaderGet[float32](r, key)
+}
+
+// Float64 returns the value associated with the given key as a float64.
+func (r *Reader) Float64(key string) float64 {
+	return ReaderGet[float64](r, key)
+}
+
+// Int returns the value associated with the given key as an int.
+func (r *Reader) Int(key string) int {
+	return ReaderGet[int](r, key)
+}
+
+// Int8 returns the value associated with the given key as an int8.
+func (r *Reader) Int8(key string) int8 {
+	return ReaderGet[int8](r, key)
+}
+
+// Int16 returns the value associated with the given key as an int16.
+func (r *Reader) Int16(key string) int16 {
+	return ReaderGet[int16](r, key)
+}
+
+// Int32 returns the value associated with the given key as an int32.
+func (r *Reader) Int32(key string) int32 {
+	return ReaderGet[int32](r, key)
+}
+
+// Int64 returns the value associated with the given key as an int64.
+func (r *Reader) Int64(key string) int64 {
+	return ReaderGet[int64](r, key)
+}
+
+// Uint returns the value associated with the given key as a uint.
+func (r *Reader) Uint(key string) uint {
+	return ReaderGet[uint](r, key)
+}
+
+// Uint8 returns the value associated with the given key as a uint8.
+func (r *Reader) Uint8(key string) uint8 {
+	return ReaderGet[uint8](r, key)
+}
+
+// Uint16 returns the value associated with the given key as a uint16.
+func (r *Reader) Uint16(key string) uint16 {
+	return ReaderGet[uint16](r, key)
+}
+
+// Uint32 returns the value associated with the given key as a uint32.
+func (r *Reader) Uint32(key string) uint32 {
+	return ReaderGet[uint32](r, key)
+}
+
+// Uint64 returns the value associated with the given key as a uint64.
+func (r *Reader) Uint64(key string) uint64 {
+	return ReaderGet[uint64](r, key)
+}
+
+// Duration returns the value associated with the given key as a time.Duration.
+func (r *Reader) Duration(key string) time.Duration {
+	return ReaderGet[time.Duration](r, key)
+}
+
+// URL returns the value associated with the given key as a *url.URL.
+func (r *Reader) URL(key string) *url.URL {
+	return ReaderGet[*url.URL](r, key)
+}
+
+// FilePath returns the value associated with the given key as a cleaned file path.
+func (r *Reader) FilePath(key string) string {
+	return collect(r, key, r.c.FilePath)
+}
+
+// ExistingFile returns the value associated with the given key as the path of an existing file.
+func (r *Reader) ExistingFile(key string) string {
+	return collect(r, key, r.c.ExistingFile)
+}
+
+// ExistingDir returns the value associated with the given key as the path of an existing directory.
+func (r *Reader) ExistingDir(key string) string {
+	return collect(r, key, r.c.ExistingDir)
+}
+
+// WritableDir returns the value associated with the given key as the path of a writable directory.
+func (r *Reader) WritableDir(key string) string {
+	return collect(r, key, r.c.WritableDir)
+}
+
+// TimeOfDay returns the value associated with the given key as a time of day
+// in HH24:MM format.
+func (r *Reader) TimeOfDay(key string) (hour, minute int) {
+	var hm = collect(r, key, func(key string) ([2]int, error) {
+		hour, minute, err := r.c.TimeOfDay(key)
+		return [2]int{hour, minute}, err
+	})
+	return hm[0], hm[1]
+}
+
+// IP returns the value associated with the given key as a net.IP.
+func (r *Reader) IP(key string) net.IP {
+	return ReaderGet[net.IP](r, key)
+}
+
+// Bytes returns the value associated with the given key as a number of bytes.
+func (r *Reader) Bytes(key string) uint64 {
+	return collect(r, key, r.c.Bytes)
+}
+
+// TimeOfDayValue returns the value associated with the given key as a TimeOfDay.
+func (r *Reader) TimeOfDayValue(key string) TimeOfDay {
+	return ReaderGet[TimeOfDay](r, key)
+}
+
+// Window returns the value associated with the given key as a Window.
+func (r *Reader) Window(key string) Window {
+	return ReaderGet[Window](r, key)
+}
+
+// Schedule returns the value associated with the given key as a Schedule.
+func (r *Reader) Schedule(key string) Schedule {
+	return ReaderGet[Schedule](r, key)
+}
+
+// Cron returns the value associated with the given key as a Cron.
+func (r *Reader) Cron(key string) Cron {
+	return ReaderGet[Cron](r, key)
+}
+
+// Time returns the value associated with the given key as a time.Time, parsed as Config.Time would.
+func (r *Reader) Time(key string, layout ...string) time.Time {
+	return collect(r, key, func(key string) (time.Time, error) { return r.c.Time(key, layout...) })
+}
+
+// Date returns the value associated with the given key as a date.
+func (r *Reader) Date(key string) time.Time {
+	return collect(r, key, r.c.Date)
+}
+
+// Location returns the value associated with the given key as a *time.Location.
+func (r *Reader) Location(key string) *time.Location {
+	return ReaderGet[*time.Location](r, key)
+}
+
+// FileMode returns the value associated with the given key as an os.FileMode.
+func (r *Reader) FileMode(key string) os.FileMode {
+	return ReaderGet[os.FileMode](r, key)
+}
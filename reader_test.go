@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReader(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+server:
+	host = localhost
+	port = 54x2
+	ttl = 5m
+	debug = maybe
+	at = 04:30
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var r = cs.Config("server").Reader()
+	var host = r.String("host")
+	var port = r.Int("port")
+	var ttl = r.Duration("ttl")
+	var debug = r.Bool("debug")
+	var hour, minute = r.TimeOfDay("at")
+	var ip = r.IP("ip")
+
+	if host != "localhost" || ttl != 5*time.Minute || hour != 4 || minute != 30 {
+		t.Errorf("expected valid values to be read but got %s, %s, %d:%d", host, ttl, hour, minute)
+	}
+	if port != 0 || debug || ip != nil {
+		t.Errorf("expected zero values for invalid keys but got %d, %t, %s", port, debug, ip)
+	}
+
+	err = r.Err()
+	var exp = `key "port" in section "server": invalid value "54x2": strconv.ParseInt: parsing "54x2": invalid syntax` + "\n" +
+		`key "debug" in section "server": invalid value "maybe": strconv.ParseBool: parsing "maybe": invalid syntax` + "\n" +
+		`key "ip" in section "server": key not found`
+	if err == nil || err.Error() != exp {
+		t.Errorf("expected:\n%s\nbut got:\n%v", exp, err)
+	}
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Error("expected the error to wrap ErrKeyNotFound")
+	}
+	var ve *ValueError
+	if !errors.As(err, &ve) || ve.Key != "port" {
+		t.Errorf("expected the first *ValueError to be for 'port' but got %v", ve)
+	}
+
+	if err := cs.Config("server").Reader().Err(); err != nil {
+		t.Errorf("did not expect an error from an unused Reader: %s", err)
+	}
+}
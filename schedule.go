@@ -0,0 +1,411 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a daily window of time, such as "22:00-06:00", optionally in a
+// particular time zone. A window whose End is before its Start wraps around
+// midnight, and a window whose Start and End are equal lasts all day.
+type Window struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// ParseWindow parses a window in the form START-END, where START and END are
+// times of day in the form HH:MM or HH:MM:SS, optionally followed by
+// whitespace and an IANA time zone name, e.g. "22:00-06:00" or
+// "09:00-17:00 America/Chicago". Whitespace around the '-' is allowed.
+func ParseWindow(s string) (Window, error) {
+	var clock, zone = splitZone(joinDashes(s))
+	var parts = strings.Split(clock, "-")
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("invalid window %q: expected HH:MM-HH:MM", s)
+	}
+	var w Window
+	var err error
+	if w.Start, err = ParseTimeOfDay(parts[0]); err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: start: %w", s, err)
+	}
+	if w.End, err = ParseTimeOfDay(parts[1]); err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: end: %w", s, err)
+	}
+	if zone != "" {
+		loc, err := parseLocation(zone)
+		if err != nil {
+			return Window{}, err
+		}
+		w.Start.Location, w.End.Location = loc, loc
+	}
+	return w, nil
+}
+
+// joinDashes removes the whitespace around each dash in s, so that ranges such
+// as "22:00 - 06:00" and "Mon - Fri" are not split at their spaces.
+func joinDashes(s string) string {
+	var parts = strings.Split(s, "-")
+	for i := range parts {
+		if i > 0 {
+			parts[i] = strings.TrimLeft(parts[i], " \t")
+		}
+		if i < len(parts)-1 {
+			parts[i] = strings.TrimRight(parts[i], " \t")
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// splitZone splits s into the text before the first whitespace and the
+// time zone name after it.
+func splitZone(s string) (clock, zone string) {
+	clock = strings.TrimSpace(s)
+	if i := strings.IndexAny(clock, " \t"); i >= 0 {
+		clock, zone = clock[:i], strings.TrimSpace(clock[i:])
+	}
+	return clock, zone
+}
+
+// String returns the window as START-END, followed by the name of the time
+// zone if it has one.
+func (w Window) String() string {
+	var start, end = w.Start, w.End
+	start.Location, end.Location = nil, nil
+	var s = start.String() + "-" + end.String()
+	if w.Start.Location != nil {
+		s += " " + w.Start.Location.String()
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (w Window) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (w *Window) UnmarshalText(text []byte) error {
+	win, err := ParseWindow(string(text))
+	if err != nil {
+		return err
+	}
+	*w = win
+	return nil
+}
+
+// Equal reports whether w and v start and end at the same times of day in the same time zone.
+func (w Window) Equal(v Window) bool {
+	return w.Start.Equal(v.Start) && w.End.Equal(v.End)
+}
+
+// location returns the time zone t is seen in by the window.
+func (w Window) location(t time.Time) *time.Location {
+	if w.Start.Location != nil {
+		return w.Start.Location
+	}
+	return t.Location()
+}
+
+// Contains reports whether t falls within the window. The window includes
+// its start but not its end.
+func (w Window) Contains(t time.Time) bool {
+	var in, _ = w.contains(t)
+	return in
+}
+
+// contains reports whether t falls within the window and, if so, whether it
+// falls in the part of a wrapping window after midnight.
+func (w Window) contains(t time.Time) (in, wrapped bool) {
+	var x = seconds(t.In(w.location(t)))
+	var start, end = w.Start.seconds(), w.End.seconds()
+	switch {
+	case start == end:
+		return true, false
+	case start < end:
+		return start <= x && x < end, false
+	case x >= start:
+		return true, false
+	default:
+		return x < end, x < end
+	}
+}
+
+// NextStart returns the first time after t at which the window starts.
+func (w Window) NextStart(t time.Time) time.Time {
+	return w.Start.Next(t)
+}
+
+func (t TimeOfDay) seconds() int {
+	return t.Hour*60*60 + t.Minute*60 + t.Second
+}
+
+func seconds(t time.Time) int {
+	var hour, minute, second = t.Clock()
+	return hour*60*60 + minute*60 + second
+}
+
+// Schedule is a weekly schedule made up of windows on particular days of the
+// week, such as "Mon-Fri 09:00-17:00; Sat 10:00-14:00".
+type Schedule []ScheduleEntry
+
+// ScheduleEntry is a window that is open on particular days of the week.
+// A window that wraps around midnight belongs to the day it starts on, so
+// "Fri 22:00-02:00" includes 01:00 on Saturday.
+type ScheduleEntry struct {
+	Days   []time.Weekday
+	Window Window
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseSchedule parses a schedule made up of entries separated by ';'.
+// Each entry is a list of days followed by a window in the form ParseWindow
+// accepts, e.g. "Mon-Fri 09:00-17:00; Sat 10:00-14:00". Days are separated
+// by ',' and may be ranges such as "Mon-Fri" or "Fri-Mon"; names may be
+// abbreviated to three letters and are case-insensitive. As in windows,
+// whitespace around a '-' is allowed. An entry without
+// days, such as "12:00-13:00", applies to every day.
+func ParseSchedule(s string) (Schedule, error) {
+	var sched Schedule
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var entry ScheduleEntry
+		var days, window = joinDashes(part), ""
+		if i := strings.IndexAny(days, " \t"); i >= 0 {
+			days, window = days[:i], strings.TrimSpace(days[i:])
+		}
+		if strings.Contains(days, ":") {
+			days, window = "sun-sat", part
+		}
+		var err error
+		if entry.Days, err = parseDays(days); err != nil {
+			return nil, fmt.Errorf("invalid schedule entry %q: %w", part, err)
+		}
+		if entry.Window, err = ParseWindow(window); err != nil {
+			return nil, fmt.Errorf("invalid schedule entry %q: %w", part, err)
+		}
+		sched = append(sched, entry)
+	}
+	if len(sched) == 0 {
+		return nil, fmt.Errorf("invalid schedule %q: expected entries such as Mon-Fri 09:00-17:00", s)
+	}
+	return sched, nil
+}
+
+func parseDays(s string) ([]time.Weekday, error) {
+	var on [7]bool
+	for _, item := range strings.Split(s, ",") {
+		var bounds = strings.Split(item, "-")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("invalid days %q", item)
+		}
+		var first, ok = weekdays[strings.ToLower(bounds[0])]
+		if !ok {
+			return nil, fmt.Errorf("unknown day %q", bounds[0])
+		}
+		var last = first
+		if len(bounds) == 2 {
+			if last, ok = weekdays[strings.ToLower(bounds[1])]; !ok {
+				return nil, fmt.Errorf("unknown day %q", bounds[1])
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			on[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	var days []time.Weekday
+	for d, ok := range on {
+		if ok {
+			days = append(days, time.Weekday(d))
+		}
+	}
+	return days, nil
+}
+
+// String returns the schedule in the form ParseSchedule accepts.
+func (s Schedule) String() string {
+	var entries = make([]string, len(s))
+	for i, entry := range s {
+		entries[i] = formatDays(entry.Days) + " " + entry.Window.String()
+	}
+	return strings.Join(entries, "; ")
+}
+
+// formatDays formats days as a list of Monday-first ranges, e.g. "Mon-Fri,Sun".
+func formatDays(days []time.Weekday) string {
+	var on [7]bool
+	for _, d := range days {
+		on[d] = true
+	}
+	var items []string
+	for i := 0; i < 7; i++ {
+		if !on[(i+1)%7] {
+			continue
+		}
+		var j = i
+		for j+1 < 7 && on[(j+2)%7] {
+			j++
+		}
+		var first, last = time.Weekday((i + 1) % 7), time.Weekday((j + 1) % 7)
+		if i == j {
+			items = append(items, first.String()[:3])
+		} else {
+			items = append(items, first.String()[:3]+"-"+last.String()[:3])
+		}
+		i = j
+	}
+	return strings.Join(items, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	sched, err := ParseSchedule(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sched
+	return nil
+}
+
+// Equal reports whether s and o describe the same schedule.
+func (s Schedule) Equal(o Schedule) bool {
+	return s.String() == o.String()
+}
+
+func (e ScheduleEntry) on(day time.Weekday) bool {
+	for _, d := range e.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t falls within one of the schedule's windows.
+func (s Schedule) Contains(t time.Time) bool {
+	for _, entry := range s {
+		var in, wrapped = entry.Window.contains(t)
+		if !in {
+			continue
+		}
+		var day = t.In(entry.Window.location(t)).Weekday()
+		if wrapped {
+			day = (day + 6) % 7
+		}
+		if entry.on(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the first time after t at which one of the schedule's
+// windows starts. The zero time.Time is returned if the schedule is empty.
+func (s Schedule) NextStart(t time.Time) time.Time {
+	var next time.Time
+	for _, entry := range s {
+		if len(entry.Days) == 0 {
+			continue
+		}
+		var start = entry.Window.NextStart(t)
+		for !entry.on(start.In(entry.Window.location(t)).Weekday()) {
+			start = entry.Window.NextStart(start)
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
+
+// Window returns the value associated with the given key as a Window,
+// parsed as ParseWindow would.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a Window.
+func (c *Config) Window(key string) (val Window, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return Window{}, err
+	}
+	return ParseWindow(str)
+}
+
+// WindowOrDefault returns the value associated with the given key as a Window.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) WindowOrDefault(key string, def Window) (val Window, used bool) {
+	var err error
+	val, err = c.Window(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Schedule returns the value associated with the given key as a Schedule,
+// parsed as ParseSchedule would.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a Schedule.
+func (c *Config) Schedule(key string) (val Schedule, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchedule(str)
+}
+
+// ScheduleOrDefault returns the value associated with the given key as a Schedule.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) ScheduleOrDefault(key string, def Schedule) (val Schedule, used bool) {
+	var err error
+	val, err = c.Schedule(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// at returns the given time on the week starting Monday 2026-10-12, in UTC.
+func at(day time.Weekday, hour, minute int) time.Time {
+	return time.Date(2026, 10, 12+(int(day)+6)%7, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParseWindow(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{"22:00-06:00", "22:00-06:00", false},
+		{"9:00-17:30:15", "09:00-17:30:15", false},
+		{"09:00-17:00 UTC", "09:00-17:00 UTC", false},
+		{"00:00-00:00", "00:00-00:00", false},
+		{"22:00", "", true},
+		{"22:00-06:00-07:00", "", true},
+		{"22:00-6", "", true},
+		{"25:00-06:00", "", true},
+		{"22:00 - 06:00", "22:00-06:00", false},
+		{"22:00 -06:00 UTC", "22:00-06:00 UTC", false},
+		{"22:00 06:00", "", true},
+		{"22:00-06:00 Mars/Olympus_Mons", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			w, err := ParseWindow(test.in)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %s", w)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error: %s", err)
+			}
+			if w.String() != test.out {
+				t.Errorf("expected %s but got %s", test.out, w)
+			}
+		})
+	}
+}
+
+func TestWindow_Contains(t *testing.T) {
+	var day, _ = ParseWindow("09:00-17:00")
+	var night, _ = ParseWindow("22:00-06:00")
+	var all, _ = ParseWindow("00:00-00:00")
+	var tests = []struct {
+		w    Window
+		t    time.Time
+		want bool
+	}{
+		{day, at(time.Monday, 9, 0), true},
+		{day, at(time.Monday, 12, 0), true},
+		{day, at(time.Monday, 17, 0), false},
+		{day, at(time.Monday, 8, 59), false},
+		{night, at(time.Monday, 23, 0), true},
+		{night, at(time.Monday, 22, 0), true},
+		{night, at(time.Monday, 3, 0), true},
+		{night, at(time.Monday, 6, 0), false},
+		{night, at(time.Monday, 12, 0), false},
+		{all, at(time.Monday, 12, 0), true},
+	}
+	for _, test := range tests {
+		if got := test.w.Contains(test.t); got != test.want {
+			t.Errorf("%s contains %s: expected %v but got %v", test.w, test.t, test.want, got)
+		}
+	}
+
+	t.Run("zone", func(t *testing.T) {
+		var w, _ = ParseWindow("09:00-17:00")
+		w.Start.Location = time.FixedZone("UTC-5", -5*60*60)
+		w.End.Location = w.Start.Location
+		if w.Contains(at(time.Monday, 10, 0)) {
+			t.Error("expected 10:00 UTC to be outside 09:00-17:00 UTC-5")
+		}
+		if !w.Contains(at(time.Monday, 15, 0)) {
+			t.Error("expected 15:00 UTC to be inside 09:00-17:00 UTC-5")
+		}
+	})
+}
+
+func TestWindow_NextStart(t *testing.T) {
+	var night, _ = ParseWindow("22:00-06:00")
+	if got, exp := night.NextStart(at(time.Monday, 23, 0)), at(time.Tuesday, 22, 0); !got.Equal(exp) {
+		t.Errorf("expected %s but got %s", exp, got)
+	}
+	if got, exp := night.NextStart(at(time.Monday, 12, 0)), at(time.Monday, 22, 0); !got.Equal(exp) {
+		t.Errorf("expected %s but got %s", exp, got)
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	var tests = []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{"Mon-Fri 09:00-17:00; Sat 10:00-14:00", "Mon-Fri 09:00-17:00; Sat 10:00-14:00", false},
+		{"mon,wed,FRIDAY 08:00-09:00", "Mon,Wed,Fri 08:00-09:00", false},
+		{"Fri-Mon 22:00-02:00 UTC;", "Mon,Fri-Sun 22:00-02:00 UTC", false},
+		{"Sun-Sat 12:00-13:00", "Mon-Sun 12:00-13:00", false},
+		{"12:00-13:00", "Mon-Sun 12:00-13:00", false},
+		{"Mon - Fri 09:00 - 17:00", "Mon-Fri 09:00-17:00", false},
+		{"12:00 - 13:00 UTC", "Mon-Sun 12:00-13:00 UTC", false},
+		{"Mon", "", true},
+		{"Funday 09:00-17:00", "", true},
+		{"Mon-Tue-Wed 09:00-17:00", "", true},
+		{"Mon 09:00", "", true},
+		{" ; ", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			s, err := ParseSchedule(test.in)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %s", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error: %s", err)
+			}
+			if s.String() != test.out {
+				t.Errorf("expected %s but got %s", test.out, s)
+			}
+		})
+	}
+}
+
+func TestSchedule_Contains(t *testing.T) {
+	var s, err = ParseSchedule("Mon-Fri 09:00-17:00; Sat 10:00-14:00; Fri 22:00-02:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tests = []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(time.Monday, 9, 0), true},
+		{at(time.Friday, 16, 59), true},
+		{at(time.Friday, 17, 0), false},
+		{at(time.Friday, 23, 0), true},
+		{at(time.Saturday, 1, 0), true},
+		{at(time.Saturday, 2, 0), false},
+		{at(time.Saturday, 12, 0), true},
+		{at(time.Sunday, 12, 0), false},
+		{at(time.Thursday, 23, 0), false},
+		{at(time.Friday, 1, 0), false},
+	}
+	for _, test := range tests {
+		if got := s.Contains(test.t); got != test.want {
+			t.Errorf("contains %s: expected %v but got %v", test.t.Format("Mon 15:04"), test.want, got)
+		}
+	}
+}
+
+func TestSchedule_NextStart(t *testing.T) {
+	var s, err = ParseSchedule("Mon-Fri 09:00-17:00; Sat 10:00-14:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tests = []struct {
+		after, exp time.Time
+	}{
+		{at(time.Monday, 8, 0), at(time.Monday, 9, 0)},
+		{at(time.Monday, 9, 0), at(time.Tuesday, 9, 0)},
+		{at(time.Friday, 12, 0), at(time.Saturday, 10, 0)},
+		{at(time.Saturday, 12, 0), at(time.Monday, 9, 0).AddDate(0, 0, 7)},
+	}
+	for _, test := range tests {
+		if got := s.NextStart(test.after); !got.Equal(test.exp) {
+			t.Errorf("after %s: expected %s but got %s", test.after, test.exp, got)
+		}
+	}
+	if got := Schedule(nil).NextStart(at(time.Monday, 0, 0)); !got.IsZero() {
+		t.Errorf("expected the zero time for an empty schedule but got %s", got)
+	}
+}
+
+func TestConfig_Schedule(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	maintenance = 22:00-06:00
+	hours = Mon-Fri 09:00-17:00; Sat 10:00-14:00
+	bad = Mon-Fri nine-to-five
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	if w, err := cfg.Window("maintenance"); err != nil || w.String() != "22:00-06:00" {
+		t.Errorf("expected 22:00-06:00 but got %s (%v)", w, err)
+	}
+	if s, err := cfg.Schedule("hours"); err != nil || len(s) != 2 {
+		t.Errorf("expected two entries but got %s (%v)", s, err)
+	}
+	if _, err := cfg.Schedule("bad"); err == nil || !strings.Contains(err.Error(), "Mon-Fri nine-to-five") {
+		t.Errorf("expected an error naming the entry but got %v", err)
+	}
+	if _, used := cfg.WindowOrDefault("bad", Window{}); !used {
+		t.Error("expected the default to be used")
+	}
+	if s, err := Get[Schedule](cfg, "hours"); err != nil || !s.Contains(at(time.Saturday, 11, 0)) {
+		t.Errorf("expected the schedule to contain Saturday 11:00 but got %s (%v)", s, err)
+	}
+
+	var a, b = New(), New()
+	a.Set("hours", "mon-fri 9:00-17:00")
+	b.Set("hours", "Mon-Fri 09:00-17:00")
+	if d := a.Diff(b, Types{"hours": TypeSchedule}); !d.Empty() {
+		t.Errorf("expected equivalent schedules to be equal but got %+v", d)
+	}
+}
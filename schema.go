@@ -0,0 +1,351 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownKey returned when a key is not declared in a Schema
+var ErrUnknownKey = errors.New("unknown key")
+
+// ErrUnknownSection returned when a configuration is not declared in a Schema
+var ErrUnknownSection = errors.New("unknown section")
+
+// ErrSectionNotFound returned when a required configuration does not exist
+var ErrSectionNotFound = errors.New("section not found")
+
+// where describes the location of a key for error messages.
+func where(section, key string) string {
+	switch {
+	case key == "":
+		return fmt.Sprintf("section %q", section)
+	case section == "":
+		return fmt.Sprintf("key %q", key)
+	default:
+		return fmt.Sprintf("key %q in section %q", key, section)
+	}
+}
+
+// KeyError records a problem with a key or section as a whole, such as a
+// required key that is missing. Key is "" if the problem is with the section.
+// Suggestion, if not "", is a known key or section that was likely meant
+// instead of an unknown one.
+type KeyError struct {
+	Section    string
+	Key        string
+	Err        error
+	Suggestion string
+}
+
+func (e *KeyError) Error() string {
+	var msg = where(e.Section, e.Key) + ": " + e.Err.Error()
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// ValueError records a value that is not valid for its key.
+type ValueError struct {
+	Section string
+	Key     string
+	Value   string
+	Err     error
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%s: invalid value %q: %s", where(e.Section, e.Key), e.Value, e.Err)
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// Severity is how serious a Problem is.
+type Severity int
+
+const (
+	// SeverityWarning problems are reported but do not make validation fail.
+	SeverityWarning Severity = iota
+	// SeverityError problems make validation fail.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Problem is something wrong found by Schema.Validate.
+// Err is a *KeyError or a *ValueError describing the problem.
+type Problem struct {
+	Severity Severity
+	Section  string
+	Key      string
+	Err      error
+}
+
+func (p *Problem) Error() string {
+	return p.Err.Error()
+}
+
+func (p *Problem) Unwrap() error {
+	return p.Err
+}
+
+// Problems are all the problems found by Schema.Validate.
+type Problems []*Problem
+
+// Err returns the problems with SeverityError joined into a single error,
+// or nil if there are none.
+func (ps Problems) Err() error {
+	var errs []error
+	for _, p := range ps {
+		if p.Severity == SeverityError {
+			errs = append(errs, p)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Schema declares the configurations and keys expected in a configuration
+// file, the Type of each value, and which keys are required or have defaults.
+// The zero value is an empty Schema ready to use.
+type Schema struct {
+	// Strict reports unknown sections and keys as errors rather than warnings.
+	Strict bool
+
+	sections map[string]*SectionSchema
+	names    []string
+}
+
+// NewSchema returns an empty Schema.
+func NewSchema() *Schema {
+	return &Schema{
+		sections: make(map[string]*SectionSchema),
+	}
+}
+
+// Section declares the named configuration, "" for the default one, and returns
+// it so that its keys can be declared. Declaring a section again returns the
+// existing declaration.
+func (s *Schema) Section(name string) *SectionSchema {
+	if ss, prs := s.sections[name]; prs {
+		return ss
+	}
+	if s.sections == nil {
+		s.sections = make(map[string]*SectionSchema)
+	}
+	var ss = &SectionSchema{
+		name: name,
+		keys: make(map[string]*KeySchema),
+	}
+	s.sections[name] = ss
+	s.names = append(s.names, name)
+	return ss
+}
+
+// SectionSchema declares the keys expected in one configuration.
+type SectionSchema struct {
+	name     string
+	required bool
+	keys     map[string]*KeySchema
+	order    []string
+}
+
+// Required marks the configuration as one that must exist.
+func (ss *SectionSchema) Required() *SectionSchema {
+	ss.required = true
+	return ss
+}
+
+// Key declares a key whose value must be of type t and returns it so that it
+// can be further described. Declaring a key again replaces its Type.
+func (ss *SectionSchema) Key(name string, t Type) *KeySchema {
+	if ks, prs := ss.keys[name]; prs {
+		ks.t = t
+		return ks
+	}
+	var ks = &KeySchema{name: name, t: t}
+	ss.keys[name] = ks
+	ss.order = append(ss.order, name)
+	return ks
+}
+
+// KeySchema declares one expected key.
+type KeySchema struct {
+	name     string
+	t        Type
+	required bool
+	def      *string
+	checks   []check
+}
+
+// Required marks the key as one that must exist.
+func (ks *KeySchema) Required() *KeySchema {
+	ks.required = true
+	return ks
+}
+
+// Default sets the value used for the key by ApplyDefaults when it is missing.
+// A key with a default is never reported as missing, but Validate reports a
+// default that cannot be parsed as the key's Type or does not satisfy its
+// constraints.
+func (ks *KeySchema) Default(val string) *KeySchema {
+	ks.def = &val
+	return ks
+}
+
+// Validate checks cfgs against the schema and returns every problem found:
+// missing required sections and keys, values that cannot be parsed as their
+// declared Type or do not satisfy their constraints, defaults that would not
+// pass those checks once applied, and unknown sections and keys. Unknown
+// sections and keys have SeverityWarning unless the schema is Strict.
+func (s *Schema) Validate(cfgs map[string]*Config) Problems {
+	var ps Problems
+	var add = func(sev Severity, section, key string, err error) {
+		ps = append(ps, &Problem{Severity: sev, Section: section, Key: key, Err: err})
+	}
+	var unknown = SeverityWarning
+	if s.Strict {
+		unknown = SeverityError
+	}
+	// checkValue reports val, the value of key in cfg, if it is not a valid
+	// ks.t or does not satisfy the constraints. what describes val in errors.
+	var checkValue = func(cfg *Config, ks *KeySchema, key, val, what string) {
+		var name = cfg.Name()
+		v, err := ks.t.value(cfg, key)
+		if err != nil {
+			add(SeverityError, name, key, &ValueError{
+				Section: name,
+				Key:     key,
+				Value:   val,
+				Err:     fmt.Errorf("%sexpected %s: %w", what, ks.t, err),
+			})
+			return
+		}
+		for _, check := range ks.checks {
+			if err := check(v, val); err != nil {
+				if what != "" {
+					err = fmt.Errorf("%s%w", what, err)
+				}
+				add(SeverityError, name, key, &ValueError{
+					Section: name,
+					Key:     key,
+					Value:   val,
+					Err:     err,
+				})
+			}
+		}
+	}
+
+	for _, name := range s.names {
+		var ss = s.sections[name]
+		var cfg = cfgs[name]
+		var missing = cfg == nil
+		if missing {
+			if ss.required {
+				add(SeverityError, name, "", &KeyError{Section: name, Err: ErrSectionNotFound})
+			}
+			// ApplyDefaults would add the section, so its defaults are still checked
+			cfg = New()
+			cfg.name = name
+		}
+		for _, key := range ss.order {
+			var ks = ss.keys[key]
+			val, ok := cfg.get(key)
+			if ok {
+				checkValue(cfg, ks, key, val, "")
+			} else if ks.def != nil {
+				var q = cfg.quiet(key)
+				q.set(key, *ks.def)
+				checkValue(q, ks, key, *ks.def, "default: ")
+			} else if ks.required && !missing {
+				add(SeverityError, name, key, &KeyError{Section: name, Key: key, Err: ErrKeyNotFound})
+			}
+		}
+		for _, key := range cfg.Keys() {
+			if _, prs := ss.keys[key]; !prs {
+				add(unknown, name, key, &KeyError{
+					Section:    name,
+					Key:        key,
+					Err:        ErrUnknownKey,
+					Suggestion: suggest(key, ss.order),
+				})
+			}
+		}
+	}
+
+	var names = make([]string, 0, len(cfgs))
+	for name := range cfgs {
+		if _, prs := s.sections[name]; !prs {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// an empty default configuration is always present, so only complain
+		// about it if something was put in it
+		if name == "" && cfgs[name].Len() == 0 {
+			continue
+		}
+		add(unknown, name, "", &KeyError{
+			Section:    name,
+			Err:        ErrUnknownSection,
+			Suggestion: suggest(name, s.names),
+		})
+	}
+	return ps
+}
+
+// ApplyDefaults sets every missing key that has a Default, adding
+// configurations to cfgs as needed.
+func (s *Schema) ApplyDefaults(cfgs map[string]*Config) {
+	for _, name := range s.names {
+		var ss = s.sections[name]
+		for _, key := range ss.order {
+			var ks = ss.keys[key]
+			if ks.def == nil {
+				continue
+			}
+			var cfg = cfgs[name]
+			if cfg == nil {
+				cfg = New()
+				cfg.name = name
+				cfgs[name] = cfg
+			}
+			if !cfg.Has(key) {
+				cfg.Set(key, *ks.def)
+			}
+		}
+	}
+}
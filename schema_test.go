@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testSchema() *Schema {
+	var s = NewSchema()
+	s.Section("").Key("every", TypeDuration)
+	var db = s.Section("database").Required()
+	db.Key("host", TypeString).Required()
+	db.Key("port", TypeInt).Default("5432")
+	db.Key("ip", TypeIP)
+	s.Section("log").Key("at", TypeTimeOfDay)
+	s.Section("metrics").Required()
+	return s
+}
+
+func TestSchema_Validate(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+every = 3m20s
+
+database:
+	port = 54x2
+	ip = 127.0.0.1
+	usrname = admin
+
+log:
+	at = 25:00
+
+cache:
+	size = 10
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var ps = testSchema().Validate(cfgs)
+	var exp = []struct {
+		severity     Severity
+		section, key string
+		err          error
+	}{
+		{SeverityError, "database", "host", ErrKeyNotFound},
+		{SeverityError, "database", "port", nil},
+		{SeverityWarning, "database", "usrname", ErrUnknownKey},
+		{SeverityError, "log", "at", nil},
+		{SeverityError, "metrics", "", ErrSectionNotFound},
+		{SeverityWarning, "cache", "", ErrUnknownSection},
+	}
+	if len(ps) != len(exp) {
+		t.Fatalf("expected %d problems but got %d: %v", len(exp), len(ps), ps)
+	}
+	for i, e := range exp {
+		var p = ps[i]
+		if p.Severity != e.severity || p.Section != e.section || p.Key != e.key {
+			t.Errorf("expected %s for %s but got %s for %s: %s",
+				e.severity, where(e.section, e.key), p.Severity, where(p.Section, p.Key), p)
+		}
+		if e.err != nil && !errors.Is(p, e.err) {
+			t.Errorf("expected %s to wrap %s", p, e.err)
+		}
+	}
+
+	var ve *ValueError
+	if !errors.As(ps[1], &ve) || ve.Value != "54x2" {
+		t.Errorf("expected a *ValueError for '54x2' but got %v", ps[1])
+	}
+	if got := ve.Error(); !strings.Contains(got, `key "port" in section "database"`) || !strings.Contains(got, "expected int") {
+		t.Errorf("expected the error to name the key and type but got %s", got)
+	}
+
+	var err2 = ps.Err()
+	if err2 == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err2.Error(), "usrname") {
+		t.Errorf("did not expect warnings in the error: %s", err2)
+	}
+}
+
+func TestSchema_Strict(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+database:
+	host = localhost
+	usrname = admin
+metrics:
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var s = testSchema()
+	if err := s.Validate(cfgs).Err(); err != nil {
+		t.Errorf("did not expect an error: %s", err)
+	}
+	s.Strict = true
+	if err := s.Validate(cfgs).Err(); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey but got %v", err)
+	}
+}
+
+func TestSchema_ApplyDefaults(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(``))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testSchema().ApplyDefaults(cfgs)
+	var db = cfgs["database"]
+	if db == nil || db.Name() != "database" {
+		t.Fatal("expected the 'database' config to be added")
+	}
+	if port, _ := db.Int("port"); port != 5432 {
+		t.Errorf("expected 5432 but got %d", port)
+	}
+	if db.Has("host") {
+		t.Error("did not expect 'host' to be set")
+	}
+}
+
+func TestSchema_zero(t *testing.T) {
+	var s Schema
+	s.Section("database").Key("host", TypeString).Required()
+	cfgs, err := Read(strings.NewReader("database:\nport = 5432\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Validate(cfgs).Err(); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected a missing key error but got %v", err)
+	}
+}
+
+func TestSchema_invalidDefault(t *testing.T) {
+	var s = NewSchema()
+	s.Section("").Key("port", TypeInt).Min(1).Default("abc")
+	s.Section("").Key("workers", TypeInt).Min(1).Default("0")
+	s.Section("cache").Key("size", TypeInt).Default("big")
+
+	cfgs, err := Read(strings.NewReader(``))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ps = s.Validate(cfgs)
+	var keys []string
+	for _, p := range ps {
+		var ve *ValueError
+		if p.Severity != SeverityError || !errors.As(p.Err, &ve) {
+			t.Errorf("unexpected problem: %s", p)
+			continue
+		}
+		keys = append(keys, p.Section+"."+p.Key+"="+ve.Value)
+	}
+	var exp = ".port=abc .workers=0 cache.size=big"
+	if got := strings.Join(keys, " "); got != exp {
+		t.Errorf("expected %q but got %q", exp, got)
+	}
+	if len(ps) > 1 && !errors.Is(ps[1].Err, ErrConstraint) {
+		t.Errorf("expected a constraint error but got %s", ps[1].Err)
+	}
+}
@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ReloadOnSignal calls Reload each time one of the given signals is received,
+// typically syscall.SIGHUP. Errors are reported to the OnError callbacks
+// rather than stopping the process.
+//
+// At least one signal must be given: with none, every signal, including
+// SIGINT and SIGTERM, would be handled as a reload, so an error is returned instead.
+//
+// The returned function stops handling the signals. Handling also stops when
+// the Watcher is closed, and an error is returned if it already has been.
+func (w *Watcher) ReloadOnSignal(sigs ...os.Signal) (stop func(), err error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signals to reload on")
+	}
+	w.mu.Lock()
+	if w.closed() {
+		w.mu.Unlock()
+		return nil, errWatcherClosed
+	}
+	w.wg.Add(1)
+	w.mu.Unlock()
+
+	var ch = make(chan os.Signal, 1)
+	var quit = make(chan struct{})
+	signal.Notify(ch, sigs...)
+	go func() {
+		defer w.wg.Done()
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-quit:
+				return
+			case <-ch:
+				w.reload()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(quit) })
+	}, nil
+}
@@ -0,0 +1,102 @@
+//go:build unix
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWatcher_ReloadOnSignal(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer w.Close()
+	w.Validate = func(cfgs map[string]*Config) error {
+		if _, err := cfgs[""].String("level"); err != nil {
+			return errors.New("level is required")
+		}
+		return nil
+	}
+
+	var updates = make(chan map[string]*Config, 10)
+	var errs = make(chan error, 10)
+	w.Subscribe(func(cfgs map[string]*Config) { updates <- cfgs })
+	w.OnError(func(err error) { errs <- err })
+	stop, err := w.ReloadOnSignal(syscall.SIGHUP)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer stop()
+
+	var hup = func() {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	writeFile(t, path, "level = debug\n")
+	hup()
+	select {
+	case cfgs := <-updates:
+		if level, _ := cfgs[""].String("level"); level != "debug" {
+			t.Errorf("expected 'debug' but got '%s'", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	writeFile(t, path, "format = json\n")
+	hup()
+	select {
+	case err := <-errs:
+		if err.Error() != "level is required" {
+			t.Errorf("unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+	if level, _ := w.Configs()[""].String("level"); level != "debug" {
+		t.Errorf("expected last good value 'debug' but got '%s'", level)
+	}
+}
+
+func TestWatcher_ReloadOnSignal_none(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer w.Close()
+	if stop, err := w.ReloadOnSignal(); err == nil {
+		stop()
+		t.Error("expected an error when no signals are given")
+	}
+}
+
+func TestWatcher_ReloadOnSignal_closed(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stop, err := w.ReloadOnSignal(syscall.SIGHUP); err == nil {
+		stop()
+		t.Error("expected an error after Close")
+	}
+}
@@ -0,0 +1,68 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import "sync/atomic"
+
+// Store holds a snapshot of Configs that can be replaced atomically while
+// other goroutines read it. Readers always see either the old or the new
+// snapshot in full, never a mix of the two.
+//
+// The zero value is an empty Store.
+type Store struct {
+	p atomic.Pointer[map[string]*Config]
+}
+
+// NewStore returns a Store holding cfgs.
+func NewStore(cfgs map[string]*Config) *Store {
+	var s = &Store{}
+	s.Store(cfgs)
+	return s
+}
+
+// Load returns the current snapshot, or nil if nothing has been stored.
+func (s *Store) Load() map[string]*Config {
+	if p := s.p.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Store replaces the current snapshot with cfgs.
+func (s *Store) Store(cfgs map[string]*Config) {
+	s.p.Store(&cfgs)
+}
+
+// Swap replaces the current snapshot with cfgs and returns the previous one.
+func (s *Store) Swap(cfgs map[string]*Config) (old map[string]*Config) {
+	if p := s.p.Swap(&cfgs); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Config returns the named Config from the current snapshot, or nil if it
+// does not exist.
+func (s *Store) Config(name string) *Config {
+	return s.Load()[name]
+}
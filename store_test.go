@@ -0,0 +1,90 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConfig_concurrent(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	n = 0
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var n int
+	IntVar(&n, cfg, "m", 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cfg.Set("n", strconv.Itoa(j))
+				cfg.Set("k"+strconv.Itoa(i), "v")
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := cfg.Int("n"); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				cfg.StringOrDefault("k0", "")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStore(t *testing.T) {
+	var s Store
+	t.Run("zero", func(t *testing.T) {
+		if s.Load() != nil {
+			t.Error("expected an empty Store")
+		}
+		if s.Config("") != nil {
+			t.Error("expected a nil Config")
+		}
+	})
+
+	var snapshot = func(v string) map[string]*Config {
+		cfgs, err := Read(strings.NewReader("a = " + v + "\nb = " + v + "\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return cfgs
+	}
+
+	var first = snapshot("0")
+	s.Store(first)
+	if old := s.Swap(snapshot("1")); old[""] != first[""] {
+		t.Error("expected Swap to return the previous snapshot")
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 2; i < 100; i++ {
+			s.Store(snapshot(strconv.Itoa(i)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cfg := s.Config("")
+			a, _ := cfg.String("a")
+			b, _ := cfg.String("b")
+			if a != b {
+				t.Errorf("expected a consistent snapshot but got a=%s and b=%s", a, b)
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import "errors"
+
+// CheckKeys reports every key in the configuration that is not one of the
+// known keys. Each unknown key is reported as a *KeyError wrapping
+// ErrUnknownKey, with a suggestion if a known key is spelled similarly, and
+// the errors are joined into one. nil is returned if every key is known.
+func (c *Config) CheckKeys(known ...string) error {
+	var set = make(map[string]bool, len(known))
+	for _, key := range known {
+		set[key] = true
+	}
+	var errs []error
+	for _, key := range c.Keys() {
+		if !set[key] {
+			errs = append(errs, &KeyError{
+				Section:    c.Name(),
+				Key:        key,
+				Err:        ErrUnknownKey,
+				Suggestion: suggest(key, known),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// suggest returns the candidate closest to s by edit distance, or "" if none
+// is close enough to be a likely misspelling.
+func suggest(s string, candidates []string) string {
+	// allow roughly one mistake per three characters, up to three
+	var limit = len(s)/3 + 1
+	if limit > 3 {
+		limit = 3
+	}
+	var best string
+	for _, c := range candidates {
+		if d := editDistance(s, c); d <= limit {
+			best, limit = c, d-1
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b, counting
+// insertions, deletions and substitutions of runes.
+func editDistance(a, b string) int {
+	var ra, rb = []rune(a), []rune(b)
+	var prev = make([]int, len(rb)+1)
+	var curr = make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			var cost = 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	var tests = []struct {
+		a, b string
+		out  int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"usrname", "username", 1},
+		{"kitten", "sitting", 3},
+		{"prot", "port", 2},
+		{"héllo", "hello", 1},
+	}
+	for _, test := range tests {
+		if d := editDistance(test.a, test.b); d != test.out {
+			t.Errorf("editDistance(%q, %q): expected %d but got %d", test.a, test.b, test.out, d)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	var known = []string{"username", "password", "host", "port"}
+	var tests = []struct {
+		in, out string
+	}{
+		{"usrname", "username"},
+		{"passwrd", "password"},
+		{"prot", "port"},
+		{"hots", "host"},
+		{"timeout", ""},
+		{"x", ""},
+	}
+	for _, test := range tests {
+		if s := suggest(test.in, known); s != test.out {
+			t.Errorf("suggest(%q): expected %q but got %q", test.in, test.out, s)
+		}
+	}
+}
+
+func TestConfig_CheckKeys(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+database:
+	usrname = admin
+	password = secret
+	colour = blue
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var db = cs.Config("database")
+
+	err = db.CheckKeys("username", "password")
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey but got %v", err)
+	}
+	var exp = `key "usrname" in section "database": unknown key; did you mean "username"?` + "\n" +
+		`key "colour" in section "database": unknown key`
+	if err.Error() != exp {
+		t.Errorf("expected:\n%s\nbut got:\n%s", exp, err)
+	}
+
+	if err := db.CheckKeys("usrname", "password", "colour"); err != nil {
+		t.Errorf("did not expect an error: %s", err)
+	}
+}
+
+func TestSchema_suggestions(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+databse:
+	host = localhost
+database:
+	hots = localhost
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var s = NewSchema()
+	s.Section("database").Key("host", TypeString)
+	var ps = s.Validate(cfgs)
+	if len(ps) != 2 {
+		t.Fatalf("expected 2 problems but got %d: %v", len(ps), ps)
+	}
+	if !strings.HasSuffix(ps[0].Error(), `did you mean "host"?`) {
+		t.Errorf("expected a suggestion of 'host' but got %s", ps[0])
+	}
+	if !strings.HasSuffix(ps[1].Error(), `did you mean "database"?`) {
+		t.Errorf("expected a suggestion of 'database' but got %s", ps[1])
+	}
+}
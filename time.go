@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of the values accepted by Date, e.g. "2026-10-16".
+const DateLayout = "2006-01-02"
+
+// Time returns the value associated with the given key as a time.Time.
+// The value is parsed with each of the given layouts in turn, as time.Parse
+// would, or with time.RFC3339 if no layouts are given. RFC 3339 values may
+// include fractional seconds, e.g. "2026-10-16T09:30:00.5-05:00".
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error naming the expected layouts is returned if the value cannot be parsed.
+func (c *Config) Time(key string, layout ...string) (val time.Time, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(str, layout)
+}
+
+// TimeOrDefault returns the value associated with the given key as a time.Time,
+// parsed as Time would.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) TimeOrDefault(key string, def time.Time, layout ...string) (val time.Time, used bool) {
+	var err error
+	val, err = c.Time(key, layout...)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Date returns the value associated with the given key as a time.Time at
+// midnight UTC on the given date. The value must be in the form "2006-01-02".
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a date.
+func (c *Config) Date(key string) (val time.Time, err error) {
+	return c.Time(key, DateLayout)
+}
+
+// DateOrDefault returns the value associated with the given key as a date, as Date would.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) DateOrDefault(key string, def time.Time) (val time.Time, used bool) {
+	var err error
+	val, err = c.Date(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+// Location returns the value associated with the given key as a *time.Location.
+// The value must be an IANA time zone name such as "America/Chicago", "UTC" or "Local",
+// and is loaded with time.LoadLocation.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the time zone is unknown.
+func (c *Config) Location(key string) (val *time.Location, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return nil, err
+	}
+	return parseLocation(str)
+}
+
+// LocationOrDefault returns the value associated with the given key as a *time.Location.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) LocationOrDefault(key string, def *time.Location) (val *time.Location, used bool) {
+	var err error
+	val, err = c.Location(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
+
+func parseTime(str string, layouts []string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+	if len(layouts) == 1 {
+		return time.Time{}, fmt.Errorf("cannot parse %q as a time: expected layout %q", str, layouts[0])
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a time: expected one of the layouts %q", str, layouts)
+}
+
+func parseLocation(str string) (*time.Location, error) {
+	// time.LoadLocation treats "" as UTC, which is more likely a mistake than intended
+	if strings.TrimSpace(str) == "" {
+		return nil, errors.New("empty time zone: expected an IANA name such as America/Chicago")
+	}
+	loc, err := time.LoadLocation(str)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: expected an IANA name such as America/Chicago", str)
+	}
+	return loc, nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_Time(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	start = 2026-10-16T09:30:00-05:00
+	frac = 2026-10-16T14:30:00.25Z
+	stamp = 16 Oct 26 09:30 CDT
+	plain = 2026-10-16 09:30
+	bad = yesterday
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+	var exp = time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)
+
+	t.Run("rfc3339", func(t *testing.T) {
+		if val, err := cfg.Time("start"); err != nil || !val.Equal(exp) {
+			t.Errorf("expected %s but got %s (%v)", exp, val, err)
+		}
+		if val, err := cfg.Time("frac"); err != nil || !val.Equal(exp.Add(250*time.Millisecond)) {
+			t.Errorf("expected fractional seconds but got %s (%v)", val, err)
+		}
+	})
+
+	t.Run("layouts", func(t *testing.T) {
+		if val, err := cfg.Time("stamp", time.RFC822); err != nil || val.Hour() != 9 || val.Minute() != 30 {
+			t.Errorf("expected 09:30 but got %s (%v)", val, err)
+		}
+		if val, err := cfg.Time("plain", time.RFC3339, "2006-01-02 15:04"); err != nil || !val.Equal(exp.Add(-5*time.Hour)) {
+			t.Errorf("expected the second layout to be used but got %s (%v)", val, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := cfg.Time("bad")
+		if err == nil || !strings.Contains(err.Error(), time.RFC3339) {
+			t.Errorf("expected the error to include the layout but got %v", err)
+		}
+		_, err = cfg.Time("bad", time.RFC822, time.Kitchen)
+		if err == nil || !strings.Contains(err.Error(), time.RFC822) || !strings.Contains(err.Error(), time.Kitchen) {
+			t.Errorf("expected the error to include the layouts but got %v", err)
+		}
+		if _, err = cfg.Time("missing"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("expected ErrKeyNotFound but got %v", err)
+		}
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		var def = time.Unix(0, 0)
+		if val, used := cfg.TimeOrDefault("start", def); used || !val.Equal(exp) {
+			t.Errorf("expected %s but got %s", exp, val)
+		}
+		if val, used := cfg.TimeOrDefault("bad", def); !used || !val.Equal(def) {
+			t.Errorf("expected the default but got %s", val)
+		}
+		if val, used := cfg.TimeOrDefault("plain", def, "2006-01-02 15:04"); used || val.IsZero() {
+			t.Errorf("expected the layout to be used but got %s", val)
+		}
+		if _, err := cfg.OptionalTime("bad", def); err == nil {
+			t.Error("expected an error for an invalid time")
+		}
+	})
+}
+
+func TestConfig_Date(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	release = 2026-10-16
+	bad = 2026-13-01
+	full = 2026-10-16T09:30:00Z
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var exp = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	if val, err := cfg.Date("release"); err != nil || !val.Equal(exp) {
+		t.Errorf("expected %s but got %s (%v)", exp, val, err)
+	}
+	for _, key := range []string{"bad", "full"} {
+		if _, err := cfg.Date(key); err == nil || !strings.Contains(err.Error(), DateLayout) {
+			t.Errorf("%s: expected the error to include the layout but got %v", key, err)
+		}
+	}
+	if val, used := cfg.DateOrDefault("bad", exp); !used || !val.Equal(exp) {
+		t.Errorf("expected the default but got %s", val)
+	}
+}
+
+func TestConfig_Location(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	tz = America/Chicago
+	utc = UTC
+	bad = Mars/Olympus_Mons
+	empty =
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	loc, err := cfg.Location("tz")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %s", err)
+	}
+	if loc.String() != "America/Chicago" {
+		t.Errorf("expected America/Chicago but got %s", loc)
+	}
+	if loc, err := cfg.Location("utc"); err != nil || loc != time.UTC {
+		t.Errorf("expected UTC but got %v (%v)", loc, err)
+	}
+	for _, key := range []string{"bad", "empty"} {
+		if _, err := cfg.Location(key); err == nil || !strings.Contains(err.Error(), "IANA") {
+			t.Errorf("%s: expected a descriptive error but got %v", key, err)
+		}
+	}
+	if val, used := cfg.LocationOrDefault("bad", time.UTC); !used || val != time.UTC {
+		t.Errorf("expected the default but got %s", val)
+	}
+
+	var p *time.Location
+	LocationVar(&p, cfg, "tz", time.UTC)
+	if p.String() != "America/Chicago" {
+		t.Errorf("expected the variable to be bound but got %s", p)
+	}
+}
+
+func TestConfig_Diff_Time(t *testing.T) {
+	var a, b = New(), New()
+	a.Set("at", "2026-10-16T09:30:00-05:00")
+	b.Set("at", "2026-10-16T14:30:00Z")
+	if d := a.Diff(b, Types{"at": TypeTime}); !d.Empty() {
+		t.Errorf("expected the same instant to be equal but got %+v", d)
+	}
+	b.Set("at", "2026-10-16T14:31:00Z")
+	if d := a.Diff(b, Types{"at": TypeTime}); len(d.Changed) != 1 {
+		t.Errorf("expected a change but got %+v", d)
+	}
+}
@@ -0,0 +1,183 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a time of day, optionally in a particular time zone, such as
+// "02:30", "17:45:30" or "02:30 Europe/Berlin".
+type TimeOfDay struct {
+	Hour   int // 0 to 23
+	Minute int // 0 to 59
+	Second int // 0 to 59
+
+	// Location is the time zone the time of day is in. If it is nil, the
+	// time of day is in the time zone of the times passed to Next and On.
+	Location *time.Location
+}
+
+// ParseTimeOfDay parses a time of day in the form HH:MM or HH:MM:SS, using a
+// 24-hour clock, optionally followed by whitespace and an IANA time zone name,
+// e.g. "9:05", "17:45:30" or "02:30 Europe/Berlin". The hour may be one or two
+// digits; minutes and seconds must be two.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	var tod TimeOfDay
+	var clock, zone = splitZone(s)
+	var parts = strings.Split(clock, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM or HH:MM:SS", s)
+	}
+	var fields = []*int{&tod.Hour, &tod.Minute, &tod.Second}
+	for i, part := range parts {
+		if !isDigits(part) || len(part) > 2 || (i > 0 && len(part) != 2) {
+			return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM or HH:MM:SS", s)
+		}
+		*fields[i] = int(part[0] - '0')
+		if len(part) == 2 {
+			*fields[i] = *fields[i]*10 + int(part[1]-'0')
+		}
+	}
+	if tod.Hour > 23 {
+		return TimeOfDay{}, errors.New("hour component must be greater than or equal to 0 and less than or equal to 23")
+	}
+	if tod.Minute > 59 {
+		return TimeOfDay{}, errors.New("minute component must be greater than or equal to 0 and less than or equal to 59")
+	}
+	if tod.Second > 59 {
+		return TimeOfDay{}, errors.New("second component must be greater than or equal to 0 and less than or equal to 59")
+	}
+
+	if zone != "" {
+		loc, err := parseLocation(zone)
+		if err != nil {
+			return TimeOfDay{}, err
+		}
+		tod.Location = loc
+	}
+	return tod, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || '9' < s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the time of day as HH:MM, or HH:MM:SS if Second is not zero,
+// followed by the name of the time zone if Location is set.
+func (t TimeOfDay) String() string {
+	var s = fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+	if t.Second != 0 {
+		s += fmt.Sprintf(":%02d", t.Second)
+	}
+	if t.Location != nil {
+		s += " " + t.Location.String()
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	tod, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+	*t = tod
+	return nil
+}
+
+// Equal reports whether t and u are the same time of day in the same time zone.
+func (t TimeOfDay) Equal(u TimeOfDay) bool {
+	return t.Hour == u.Hour && t.Minute == u.Minute && t.Second == u.Second &&
+		equalLocations(t.Location, u.Location)
+}
+
+// On returns the time of day on the calendar date of date, as seen in
+// date's time zone. The result is in t's time zone, or in date's if t has none.
+// Times that do not exist on that date, because of a daylight saving
+// transition, are normalized as time.Date does.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	var loc = t.Location
+	if loc == nil {
+		loc = date.Location()
+	}
+	var year, month, day = date.Date()
+	return time.Date(year, month, day, t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+// Next returns the first time after "after" at which the time of day occurs.
+// The result is in t's time zone, or in after's if t has none.
+func (t TimeOfDay) Next(after time.Time) time.Time {
+	var loc = t.Location
+	if loc == nil {
+		loc = after.Location()
+	}
+	var local = after.In(loc)
+	var next = t.On(local)
+	for !next.After(after) {
+		local = local.AddDate(0, 0, 1)
+		next = t.On(local)
+	}
+	return next
+}
+
+// TimeOfDayValue returns the value associated with the given key as a TimeOfDay,
+// parsed as ParseTimeOfDay would.
+// If the key does not exist, ErrKeyNotFound is returned.
+// An error is returned if the value cannot be parsed into a TimeOfDay.
+func (c *Config) TimeOfDayValue(key string) (val TimeOfDay, err error) {
+	str, err := c.String(key)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return ParseTimeOfDay(str)
+}
+
+// TimeOfDayValueOrDefault returns the value associated with the given key as a TimeOfDay.
+// If the key does not exist or cannot be parsed appropriately, the default value "def" is returned.
+// "used" will be true if the default value was used.
+func (c *Config) TimeOfDayValueOrDefault(key string, def TimeOfDay) (val TimeOfDay, used bool) {
+	var err error
+	val, err = c.TimeOfDayValue(key)
+	if err != nil {
+		c.defaulted(key, err)
+		return def, true
+	}
+	return val, false
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	var tests = []struct {
+		in   string
+		out  string
+		zone string
+		err  bool
+	}{
+		{"02:30", "02:30", "", false},
+		{"9:05", "09:05", "", false},
+		{"23:59:59", "23:59:59", "", false},
+		{"00:00:00", "00:00", "", false},
+		{" 17:45 ", "17:45", "", false},
+		{"02:30 UTC", "02:30 UTC", "UTC", false},
+		{"02:30\tUTC", "02:30 UTC", "UTC", false},
+		{"12:30abc", "", "", true},
+		{"12:30 ", "12:30", "", false},
+		{"12:3", "", "", true},
+		{"123:30", "", "", true},
+		{"24:00", "", "", true},
+		{"12:60", "", "", true},
+		{"12:30:60", "", "", true},
+		{"12:30:00:00", "", "", true},
+		{"-1:30", "", "", true},
+		{"+1:30", "", "", true},
+		{"12", "", "", true},
+		{"", "", "", true},
+		{"02:30 Mars/Olympus_Mons", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			tod, err := ParseTimeOfDay(test.in)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error but got %s", tod)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error: %s", err)
+			}
+			if tod.String() != test.out {
+				t.Errorf("expected %s but got %s", test.out, tod)
+			}
+		})
+	}
+}
+
+func TestTimeOfDay_On(t *testing.T) {
+	var tod = TimeOfDay{Hour: 2, Minute: 30}
+	var date = time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	if got, exp := tod.On(date), time.Date(2026, 10, 16, 2, 30, 0, 0, time.UTC); !got.Equal(exp) {
+		t.Errorf("expected %s but got %s", exp, got)
+	}
+
+	var east = time.FixedZone("UTC+3", 3*60*60)
+	tod.Location = east
+	if got, exp := tod.On(date), time.Date(2026, 10, 16, 2, 30, 0, 0, east); !got.Equal(exp) {
+		t.Errorf("expected %s but got %s", exp, got)
+	}
+}
+
+func TestTimeOfDay_Next(t *testing.T) {
+	var tod = TimeOfDay{Hour: 2, Minute: 30}
+	var tests = []struct {
+		after, exp time.Time
+	}{
+		{time.Date(2026, 10, 16, 1, 0, 0, 0, time.UTC), time.Date(2026, 10, 16, 2, 30, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 16, 2, 30, 0, 0, time.UTC), time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC)},
+		{time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 2, 30, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		if got := tod.Next(test.after); !got.Equal(test.exp) {
+			t.Errorf("after %s: expected %s but got %s", test.after, test.exp, got)
+		}
+	}
+
+	t.Run("zone", func(t *testing.T) {
+		var east = time.FixedZone("UTC+3", 3*60*60)
+		var tod = TimeOfDay{Hour: 2, Minute: 30, Location: east}
+		// 22:00 UTC is 01:00 the next day at UTC+3
+		var after = time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)
+		if got, exp := tod.Next(after), time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC); !got.Equal(exp) {
+			t.Errorf("expected %s but got %s", exp, got)
+		}
+	})
+
+	t.Run("daylight saving", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Berlin")
+		if err != nil {
+			t.Skipf("time zone database unavailable: %s", err)
+		}
+		var tod = TimeOfDay{Hour: 12, Location: loc}
+		var after = time.Date(2026, 3, 28, 12, 0, 0, 0, loc)
+		if got, exp := tod.Next(after), time.Date(2026, 3, 29, 12, 0, 0, 0, loc); !got.Equal(exp) || got.Sub(after) != 23*time.Hour {
+			t.Errorf("expected %s but got %s", exp, got)
+		}
+	})
+}
+
+func TestConfig_TimeOfDayValue(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	nightly = 02:30:15 UTC
+	short = 9:05
+	bad = 12:30abc
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	if tod, err := cfg.TimeOfDayValue("nightly"); err != nil || tod.String() != "02:30:15 UTC" {
+		t.Errorf("expected 02:30:15 UTC but got %s (%v)", tod, err)
+	}
+	if _, err := cfg.TimeOfDayValue("bad"); err == nil {
+		t.Error("expected an error for trailing characters")
+	}
+	var def = TimeOfDay{Hour: 1}
+	if tod, used := cfg.TimeOfDayValueOrDefault("bad", def); !used || !tod.Equal(def) {
+		t.Errorf("expected the default but got %s", tod)
+	}
+	if tod, err := Get[TimeOfDay](cfg, "short"); err != nil || !tod.Equal(TimeOfDay{Hour: 9, Minute: 5}) {
+		t.Errorf("expected 09:05 but got %s (%v)", tod, err)
+	}
+
+	t.Run("strict TimeOfDay", func(t *testing.T) {
+		if _, _, err := cfg.TimeOfDay("bad"); err == nil {
+			t.Error("expected an error for trailing characters")
+		}
+		if _, _, err := cfg.TimeOfDay("nightly"); err == nil {
+			t.Error("expected an error for seconds and a time zone")
+		}
+		if _, _, err := FromMap(map[string]string{"at": "12:30:00"}).TimeOfDay("at"); err == nil {
+			t.Error("expected an error for zero seconds")
+		}
+		if hour, minute, err := cfg.TimeOfDay("short"); err != nil || hour != 9 || minute != 5 {
+			t.Errorf("expected 9:05 but got %d:%d (%v)", hour, minute, err)
+		}
+	})
+}
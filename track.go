@@ -0,0 +1,213 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultReason is why a default value was used in place of a key's value.
+type DefaultReason int
+
+const (
+	// DefaultMissing means the key does not exist.
+	DefaultMissing DefaultReason = iota
+	// DefaultInvalid means the key's value could not be parsed.
+	DefaultInvalid
+)
+
+func (r DefaultReason) String() string {
+	if r == DefaultMissing {
+		return "missing"
+	}
+	return "invalid"
+}
+
+// DefaultUse records that a default value was used for a key.
+type DefaultUse struct {
+	Key    string
+	Reason DefaultReason
+	// Value is the value that could not be parsed, if the Reason is DefaultInvalid.
+	Value string
+	// Err is the error that caused the default to be used.
+	Err error
+}
+
+// Report describes how the keys of a Config were used since tracking began.
+type Report struct {
+	// Section is the name of the Config.
+	Section string
+	// Read are the keys that were read, whether or not they exist, in the
+	// order they were first read.
+	Read []string
+	// Unused are the keys that exist but were never read, in the order of Keys.
+	Unused []string
+	// Defaulted are the keys for which an *OrDefault method or an Optional
+	// getter, such as OptionalInt, used its default, in the order they were
+	// first defaulted. Only the most recent use is kept.
+	Defaulted []DefaultUse
+}
+
+// String formats the report for logging, one line per unused or defaulted key.
+func (r Report) String() string {
+	var b strings.Builder
+	for _, key := range r.Unused {
+		fmt.Fprintf(&b, "%s: never read\n", where(r.Section, key))
+	}
+	for _, d := range r.Defaulted {
+		if d.Reason == DefaultMissing {
+			fmt.Fprintf(&b, "%s: default used: missing\n", where(r.Section, d.Key))
+		} else {
+			fmt.Fprintf(&b, "%s: default used: invalid value %q: %s\n", where(r.Section, d.Key), d.Value, d.Err)
+		}
+	}
+	return b.String()
+}
+
+type tracker struct {
+	mu        sync.Mutex
+	read      map[string]bool
+	order     []string
+	defaulted map[string]int
+	defaults  []DefaultUse
+}
+
+// Track starts recording which keys are read and which keys have defaults
+// used in their place, discarding anything recorded before.
+// Reads made by the package itself, for example by Schema.Validate or Diff,
+// are not recorded.
+func (c *Config) Track() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tracker = &tracker{
+		read:      make(map[string]bool),
+		defaulted: make(map[string]int),
+	}
+}
+
+// Report returns what has been recorded since Track was called.
+// The Report is empty if Track was never called.
+func (c *Config) Report() Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var r = Report{Section: c.name}
+	var t = c.tracker
+	if t == nil {
+		return r
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r.Read = append(r.Read, t.order...)
+	for _, key := range c.keys {
+		if !t.read[key] {
+			r.Unused = append(r.Unused, key)
+		}
+	}
+	r.Defaulted = append(r.Defaulted, t.defaults...)
+	return r
+}
+
+// read records that key was read, if tracking.
+func (c *Config) read(key string) {
+	c.mu.RLock()
+	var t = c.tracker
+	c.mu.RUnlock()
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.read[key] {
+		t.read[key] = true
+		t.order = append(t.order, key)
+	}
+}
+
+// OnDefault registers fn to be called whenever an *OrDefault method or an
+// Optional getter, such as OptionalInt, uses its default value for a key.
+// raw is the value that could not be parsed, or "" if the key is missing,
+// and err is the error that caused the default to be used, ErrKeyNotFound
+// for a missing key.
+func (c *Config) OnDefault(fn func(key, raw string, err error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDefault = append(c.onDefault, fn)
+}
+
+// defaulted records that the default was used for key because of err, if
+// tracking, and calls the OnDefault callbacks.
+func (c *Config) defaulted(key string, err error) {
+	var d = DefaultUse{Key: key, Reason: DefaultMissing, Err: err}
+	c.mu.RLock()
+	var t, hooks = c.tracker, c.onDefault
+	if val, ok := c.m[key]; ok {
+		d.Reason, d.Value = DefaultInvalid, val
+	}
+	c.mu.RUnlock()
+	for _, fn := range hooks {
+		fn(key, d.Value, err)
+	}
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i, ok := t.defaulted[key]; ok {
+		t.defaults[i] = d
+		return
+	}
+	t.defaulted[key] = len(t.defaults)
+	t.defaults = append(t.defaults, d)
+}
+
+// Track calls Track on every Config.
+func (cs *Configs) Track() {
+	cs.lazyInit()
+	for _, name := range cs.names {
+		cs.m[name].Track()
+	}
+}
+
+// OnDefault registers fn with every Config, as Config.OnDefault would,
+// passing it the name of the Config as well.
+func (cs *Configs) OnDefault(fn func(section, key, raw string, err error)) {
+	cs.lazyInit()
+	for _, name := range cs.names {
+		var name = name
+		cs.m[name].OnDefault(func(key, raw string, err error) {
+			fn(name, key, raw, err)
+		})
+	}
+}
+
+// Report returns the Report of every Config in order.
+func (cs *Configs) Report() []Report {
+	cs.lazyInit()
+	var rs = make([]Report, 0, len(cs.names))
+	for _, name := range cs.names {
+		rs = append(rs, cs.m[name].Report())
+	}
+	return rs
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Track(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	host = localhost
+	port = 54x2
+	usrname = admin
+	debug = true
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg.String("host")
+		if r := cfg.Report(); r.Read != nil || r.Unused != nil || r.Defaulted != nil {
+			t.Errorf("expected an empty report but got %+v", r)
+		}
+	})
+
+	cfg.Track()
+	cfg.StringOrDefault("host", "example.com")
+	cfg.IntOrDefault("port", 8080)
+	cfg.StringOrDefault("username", "root")
+	cfg.BoolOrDefault("debug", false)
+	cfg.IntOrDefault("port", 9090)
+
+	// reads by the package itself are not recorded
+	var s = NewSchema()
+	s.Section("").Key("usrname", TypeString)
+	s.Validate(cfgs)
+	cfg.Diff(New(), Types{"usrname": TypeString})
+
+	var r = cfg.Report()
+	t.Run("Read", func(t *testing.T) {
+		var exp = []string{"host", "port", "username", "debug"}
+		if !reflect.DeepEqual(r.Read, exp) {
+			t.Errorf("expected %#v but got %#v", exp, r.Read)
+		}
+	})
+
+	t.Run("Unused", func(t *testing.T) {
+		var exp = []string{"usrname"}
+		if !reflect.DeepEqual(r.Unused, exp) {
+			t.Errorf("expected %#v but got %#v", exp, r.Unused)
+		}
+	})
+
+	t.Run("Defaulted", func(t *testing.T) {
+		if len(r.Defaulted) != 2 {
+			t.Fatalf("expected 2 defaulted keys but got %+v", r.Defaulted)
+		}
+		var port, username = r.Defaulted[0], r.Defaulted[1]
+		if port.Key != "port" || port.Reason != DefaultInvalid || port.Value != "54x2" || port.Err == nil {
+			t.Errorf("expected 'port' to be defaulted as invalid but got %+v", port)
+		}
+		if username.Key != "username" || username.Reason != DefaultMissing || !errors.Is(username.Err, ErrKeyNotFound) {
+			t.Errorf("expected 'username' to be defaulted as missing but got %+v", username)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		var exp = `key "usrname": never read` + "\n" +
+			`key "port": default used: invalid value "54x2": strconv.ParseInt: parsing "54x2": invalid syntax` + "\n" +
+			`key "username": default used: missing` + "\n"
+		if r.String() != exp {
+			t.Errorf("expected:\n%s\nbut got:\n%s", exp, r)
+		}
+	})
+
+	cfg.Track()
+	if r := cfg.Report(); len(r.Read) != 0 || len(r.Unused) != 4 {
+		t.Errorf("expected Track to reset the report but got %+v", r)
+	}
+}
+
+func TestConfigs_Track(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+database:
+	host = localhost
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cs.Track()
+	cs.Config("database").IntOrDefault("port", 5432)
+
+	var rs = cs.Report()
+	if len(rs) != 2 || rs[1].Section != "database" {
+		t.Fatalf("expected reports for '' and 'database' but got %+v", rs)
+	}
+	var exp = `key "host" in section "database": never read` + "\n" +
+		`key "port" in section "database": default used: missing` + "\n"
+	if rs[1].String() != exp {
+		t.Errorf("expected:\n%s\nbut got:\n%s", exp, rs[1])
+	}
+}
+
+func TestConfig_OnDefault(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	bad = 54x2
+	good = 1
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	type call struct {
+		key, raw string
+		err      error
+	}
+	var calls []call
+	cfg.OnDefault(func(key, raw string, err error) {
+		calls = append(calls, call{key, raw, err})
+	})
+
+	var getters = map[string]func(key string){
+		"Bool":      func(key string) { cfg.BoolOrDefault(key, false) },
+		"Float32":   func(key string) { cfg.Float32OrDefault(key, 0) },
+		"Float64":   func(key string) { cfg.Float64OrDefault(key, 0) },
+		"Int":       func(key string) { cfg.IntOrDefault(key, 0) },
+		"Int32":     func(key string) { cfg.Int32OrDefault(key, 0) },
+		"Int64":     func(key string) { cfg.Int64OrDefault(key, 0) },
+		"Uint":      func(key string) { cfg.UintOrDefault(key, 0) },
+		"Uint32":    func(key string) { cfg.Uint32OrDefault(key, 0) },
+		"Uint64":    func(key string) { cfg.Uint64OrDefault(key, 0) },
+		"Duration":  func(key string) { cfg.DurationOrDefault(key, 0) },
+		"TimeOfDay": func(key string) { cfg.TimeOfDayOrDefault(key, 0, 0) },
+		"IP":        func(key string) { cfg.IPOrDefault(key, nil) },
+	}
+	for name, get := range getters {
+		t.Run(name, func(t *testing.T) {
+			calls = nil
+			get("bad")
+			get("missing")
+			if len(calls) != 2 {
+				t.Fatalf("expected 2 calls but got %+v", calls)
+			}
+			if calls[0].key != "bad" || calls[0].raw != "54x2" || calls[0].err == nil {
+				t.Errorf("expected a call for the invalid key but got %+v", calls[0])
+			}
+			if calls[1].key != "missing" || calls[1].raw != "" || calls[1].err != ErrKeyNotFound {
+				t.Errorf("expected a call for the missing key but got %+v", calls[1])
+			}
+		})
+	}
+
+	for _, get := range []func(key string){
+		func(key string) { cfg.StringOrDefault(key, "") },
+		func(key string) { cfg.URLOrDefault(key, nil) },
+		func(key string) { cfg.FilePathOrDefault(key, "") },
+	} {
+		calls = nil
+		get("good")
+		get("missing")
+		if len(calls) != 1 || calls[0].key != "missing" {
+			t.Errorf("expected a call for the missing key only but got %+v", calls)
+		}
+	}
+}
+
+func TestConfigs_OnDefault(t *testing.T) {
+	cs, err := ReadConfigs(strings.NewReader(`
+database:
+	port = 54x2
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []string
+	cs.OnDefault(func(section, key, raw string, err error) {
+		got = append(got, section+"."+key+"="+raw)
+	})
+	cs.Config("database").IntOrDefault("port", 5432)
+	cs.Config("").IntOrDefault("port", 5432)
+
+	var exp = []string{"database.port=54x2", ".port="}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %#v but got %#v", exp, got)
+	}
+}
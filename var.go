@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// bind registers fn to be called whenever the value of key is changed with
+// Set, Delete, Merge or ApplyDefaults, and calls it once immediately so the
+// bound variable starts with the current value.
+func (c *Config) bind(key string, fn func()) {
+	c.mu.Lock()
+	if c.binds == nil {
+		c.binds = make(map[string][]func())
+	}
+	c.binds[key] = append(c.binds[key], fn)
+	c.mu.Unlock()
+	fn()
+}
+
+// StringVar binds the string variable p to the given key.
+// p is set to the value StringOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func StringVar(p *string, c *Config, key string, def string) {
+	VarOf(p, c, key, def)
+}
+
+// BoolVar binds the bool variable p to the given key.
+// p is set to the value BoolOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func BoolVar(p *bool, c *Config, key string, def bool) {
+	VarOf(p, c, key, def)
+}
+
+// Float32Var binds the float32 variable p to the given key.
+// p is set to the value Float32OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Float32Var(p *float32, c *Config, key string, def float32) {
+	VarOf(p, c, key, def)
+}
+
+// Float64Var binds the float64 variable p to the given key.
+// p is set to the value Float64OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Float64Var(p *float64, c *Config, key string, def float64) {
+	VarOf(p, c, key, def)
+}
+
+// IntVar binds the int variable p to the given key.
+// p is set to the value IntOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func IntVar(p *int, c *Config, key string, def int) {
+	VarOf(p, c, key, def)
+}
+
+// Int8Var binds the int8 variable p to the given key.
+// p is set to the value Int8OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Int8Var(p *int8, c *Config, key string, def int8) {
+	VarOf(p, c, key, def)
+}
+
+// Int16Var binds the int16 variable p to the given key.
+// p is set to the value Int16OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Int16Var(p *int16, c *Config, key string, def int16) {
+	VarOf(p, c, key, def)
+}
+
+// Int32Var binds the int32 variable p to the given key.
+// p is set to the value Int32OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Int32Var(p *int32, c *Config, key string, def int32) {
+	VarOf(p, c, key, def)
+}
+
+// Int64Var binds the int64 variable p to the given key.
+// p is set to the value Int64OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Int64Var(p *int64, c *Config, key string, def int64) {
+	VarOf(p, c, key, def)
+}
+
+// UintVar binds the uint variable p to the given key.
+// p is set to the value UintOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func UintVar(p *uint, c *Config, key string, def uint) {
+	VarOf(p, c, key, def)
+}
+
+// Uint8Var binds the uint8 variable p to the given key.
+// p is set to the value Uint8OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Uint8Var(p *uint8, c *Config, key string, def uint8) {
+	VarOf(p, c, key, def)
+}
+
+// Uint16Var binds the uint16 variable p to the given key.
+// p is set to the value Uint16OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Uint16Var(p *uint16, c *Config, key string, def uint16) {
+	VarOf(p, c, key, def)
+}
+
+// Uint32Var binds the uint32 variable p to the given key.
+// p is set to the value Uint32OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Uint32Var(p *uint32, c *Config, key string, def uint32) {
+	VarOf(p, c, key, def)
+}
+
+// Uint64Var binds the uint64 variable p to the given key.
+// p is set to the value Uint64OrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func Uint64Var(p *uint64, c *Config, key string, def uint64) {
+	VarOf(p, c, key, def)
+}
+
+// DurationVar binds the time.Duration variable p to the given key.
+// p is set to the value DurationOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func DurationVar(p *time.Duration, c *Config, key string, def time.Duration) {
+	VarOf(p, c, key, def)
+}
+
+// URLVar binds the *url.URL variable p to the given key.
+// p is set to the value URLOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func URLVar(p **url.URL, c *Config, key string, def *url.URL) {
+	VarOf(p, c, key, def)
+}
+
+// FilePathVar binds the string variable p to the given key, interpreted as a file path.
+// p is set to the value FilePathOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func FilePathVar(p *string, c *Config, key string, def string) {
+	c.bind(key, func() { *p, _ = c.FilePathOrDefault(key, def) })
+}
+
+// TimeOfDayVar binds the hour and minute variables to the given key.
+// hour and minute are set to the values TimeOfDayOrDefault would return and are
+// updated each time the key is changed with Set, Delete, Merge or ApplyDefaults.
+func TimeOfDayVar(hour, minute *int, c *Config, key string, defHour, defMinute int) {
+	c.bind(key, func() { *hour, *minute, _ = c.TimeOfDayOrDefault(key, defHour, defMinute) })
+}
+
+// IPVar binds the net.IP variable p to the given key.
+// p is set to the value IPOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func IPVar(p *net.IP, c *Config, key string, def net.IP) {
+	VarOf(p, c, key, def)
+}
+
+// BytesVar binds the uint64 variable p to the given key, interpreted as a number of bytes.
+// p is set to the value BytesOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func BytesVar(p *uint64, c *Config, key string, def uint64) {
+	c.bind(key, func() { *p, _ = c.BytesOrDefault(key, def) })
+}
+
+// TimeOfDayValueVar binds the TimeOfDay variable p to the given key.
+// p is set to the value TimeOfDayValueOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func TimeOfDayValueVar(p *TimeOfDay, c *Config, key string, def TimeOfDay) {
+	VarOf(p, c, key, def)
+}
+
+// WindowVar binds the Window variable p to the given key.
+// p is set to the value WindowOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func WindowVar(p *Window, c *Config, key string, def Window) {
+	VarOf(p, c, key, def)
+}
+
+// ScheduleVar binds the Schedule variable p to the given key.
+// p is set to the value ScheduleOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func ScheduleVar(p *Schedule, c *Config, key string, def Schedule) {
+	VarOf(p, c, key, def)
+}
+
+// CronVar binds the Cron variable p to the given key.
+// p is set to the value CronOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func CronVar(p *Cron, c *Config, key string, def Cron) {
+	VarOf(p, c, key, def)
+}
+
+// TimeVar binds the time.Time variable p to the given key.
+// p is set to the value TimeOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func TimeVar(p *time.Time, c *Config, key string, def time.Time, layout ...string) {
+	c.bind(key, func() { *p, _ = c.TimeOrDefault(key, def, layout...) })
+}
+
+// DateVar binds the time.Time variable p to the date associated with the given key.
+// p is set to the value DateOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func DateVar(p *time.Time, c *Config, key string, def time.Time) {
+	c.bind(key, func() { *p, _ = c.DateOrDefault(key, def) })
+}
+
+// LocationVar binds the *time.Location variable p to the given key.
+// p is set to the value LocationOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func LocationVar(p **time.Location, c *Config, key string, def *time.Location) {
+	VarOf(p, c, key, def)
+}
+
+// FileModeVar binds the os.FileMode variable p to the given key.
+// p is set to the value FileModeOrDefault would return and is updated each time
+// the key is changed with Set, Delete, Merge or ApplyDefaults.
+func FileModeVar(p *os.FileMode, c *Config, key string, def os.FileMode) {
+	VarOf(p, c, key, def)
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntVar(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	port = 1234
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var port int
+	IntVar(&port, cfg, "port", 8080)
+	t.Run("initial", func(t *testing.T) {
+		if port != 1234 {
+			t.Errorf("expected 1234 but got %d", port)
+		}
+	})
+
+	cfg.Set("port", "4321")
+	t.Run("set", func(t *testing.T) {
+		if port != 4321 {
+			t.Errorf("expected 4321 but got %d", port)
+		}
+	})
+
+	cfg.Set("port", "juliet")
+	t.Run("invalid", func(t *testing.T) {
+		if port != 8080 {
+			t.Errorf("expected default 8080 but got %d", port)
+		}
+	})
+}
+
+func TestDurationVar(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(``))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var every time.Duration
+	DurationVar(&every, cfg, "every", time.Minute)
+	t.Run("missing", func(t *testing.T) {
+		if every != time.Minute {
+			t.Errorf("expected %s but got %s", time.Minute, every)
+		}
+	})
+
+	cfg.Set("every", "3m20s")
+	t.Run("set", func(t *testing.T) {
+		if every != 200*time.Second {
+			t.Errorf("expected %s but got %s", 200*time.Second, every)
+		}
+	})
+}
+
+func TestTimeOfDayVar(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	at = 11:26
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var hour, minute int
+	TimeOfDayVar(&hour, &minute, cfg, "at", 1, 2)
+	if hour != 11 || minute != 26 {
+		t.Errorf("expected 11:26 but got %d:%d", hour, minute)
+	}
+	cfg.Set("at", "23:59")
+	if hour != 23 || minute != 59 {
+		t.Errorf("expected 23:59 but got %d:%d", hour, minute)
+	}
+}
+
+func TestVar_multiple(t *testing.T) {
+	cfgs, err := Read(strings.NewReader(`
+	ip = 127.0.0.1
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := cfgs[""]
+
+	var a, b net.IP
+	var other string
+	IPVar(&a, cfg, "ip", nil)
+	IPVar(&b, cfg, "ip", nil)
+	StringVar(&other, cfg, "other", "unchanged")
+
+	cfg.Set("ip", "192.168.1.1")
+	exp := net.ParseIP("192.168.1.1")
+	if !exp.Equal(a) || !exp.Equal(b) {
+		t.Errorf("expected both variables to be %s but got %s and %s", exp, a, b)
+	}
+	if other != "unchanged" {
+		t.Errorf("expected 'unchanged' but got '%s'", other)
+	}
+}
+
+func TestIntVar_DeleteMergeApplyDefaults(t *testing.T) {
+	var cfg = New()
+	cfg.Set("port", "8080")
+	var port int
+	IntVar(&port, cfg, "port", 80)
+
+	cfg.Delete("port")
+	if port != 80 {
+		t.Errorf("expected Delete to restore the default but got %d", port)
+	}
+	var other = New()
+	other.Set("port", "9090")
+	if err := cfg.Merge(other, MergeReplace); err != nil || port != 9090 {
+		t.Errorf("expected Merge to update the variable but got %d (%v)", port, err)
+	}
+	cfg.Delete("port")
+	var s = NewSchema()
+	s.Section("").Key("port", TypeInt).Default("7070")
+	s.ApplyDefaults(map[string]*Config{"": cfg})
+	if port != 7070 {
+		t.Errorf("expected ApplyDefaults to update the variable but got %d", port)
+	}
+}
@@ -0,0 +1,361 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultDebounce is the Debounce used by a Watcher when none is set.
+const DefaultDebounce = 100 * time.Millisecond
+
+// DefaultPollInterval is the PollInterval used by a Watcher when none is set.
+const DefaultPollInterval = 2 * time.Second
+
+// Watcher re-reads one or more configuration files when they change and
+// delivers the new Configs to subscribers.
+//
+// On Linux, changes are detected with inotify. Elsewhere, or if inotify is
+// unavailable, the files are polled for changes in size and modification time.
+// If re-reading or validation fails, the last good Configs are kept and the
+// error is reported to the OnError callbacks instead.
+//
+// Each reload produces new Configs, so settings made on the old ones, such as
+// SetDecimalIntegers, SetPathOptions, Track or OnDefault, do not carry over.
+// Apply them in the Load function given to NewWatcherFunc so they are applied
+// to every read. Variables bound with IntVar and the like follow the Config
+// they were bound to, so re-establish them in a Subscribe callback.
+type Watcher struct {
+	// Debounce is how long the Watcher waits after the last change it sees
+	// before re-reading the files. Zero means DefaultDebounce.
+	Debounce time.Duration
+
+	// PollInterval is how often the files are checked when they are polled.
+	// Zero means DefaultPollInterval.
+	PollInterval time.Duration
+
+	// Validate, if set, is called with newly read Configs before they replace
+	// the current ones. If it returns an error, the new Configs are discarded.
+	// It is not applied to the Configs read by NewWatcher.
+	Validate func(cfgs map[string]*Config) error
+
+	// Load reads the files, both initially and on each reload. It is ReadFiles
+	// unless the Watcher was made with NewWatcherFunc.
+	Load func(paths ...string) (map[string]*Config, error)
+
+	reloading sync.Mutex
+
+	paths   []string
+	changed chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+	closer  io.Closer
+	once    sync.Once
+
+	store Store
+
+	mu         sync.Mutex
+	subs       []func(map[string]*Config)
+	errs       []func(error)
+	changes    []func(prev, next map[string]*Config)
+	pending    []reloaded // reloads whose callbacks have yet to run
+	delivering bool       // whether a Reload is running callbacks
+}
+
+// reloaded records the Configs replaced by a reload and the ones replacing them.
+type reloaded struct {
+	prev, next map[string]*Config
+}
+
+// NewWatcher reads the named files with ReadFiles and returns a Watcher for them.
+// An error is returned if the initial read fails.
+// Call Start to begin watching for changes.
+func NewWatcher(paths ...string) (*Watcher, error) {
+	return NewWatcherFunc(ReadFiles, paths...)
+}
+
+// NewWatcherFunc is like NewWatcher but reads the named files with load,
+// both initially and on each reload. load is typically ReadFiles followed by
+// applying settings to the Configs it returns, e.g.
+//
+//	w, err := config.NewWatcherFunc(func(paths ...string) (map[string]*config.Config, error) {
+//		cfgs, err := config.ReadFiles(paths...)
+//		if err == nil {
+//			cfgs[""].SetDecimalIntegers(true)
+//		}
+//		return cfgs, err
+//	}, "app.conf")
+func NewWatcherFunc(load func(paths ...string) (map[string]*Config, error), paths ...string) (*Watcher, error) {
+	var abs = make([]string, len(paths))
+	for i, path := range paths {
+		var err error
+		if abs[i], err = filepath.Abs(path); err != nil {
+			return nil, err
+		}
+	}
+	cfgs, err := load(abs...)
+	if err != nil {
+		return nil, err
+	}
+	var w = &Watcher{
+		Load:    load,
+		paths:   abs,
+		changed: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	w.store.Store(cfgs)
+	return w, nil
+}
+
+// Configs returns the most recent Configs that were read successfully.
+func (w *Watcher) Configs() map[string]*Config {
+	return w.store.Load()
+}
+
+// Subscribe registers fn to be called with the new Configs after each
+// successful re-read. Subscribers are called one at a time, in the order
+// they were registered. A subscriber may call Reload; see Reload.
+func (w *Watcher) Subscribe(fn func(cfgs map[string]*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, fn)
+}
+
+// OnError registers fn to be called when re-reading the files fails.
+func (w *Watcher) OnError(fn func(err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.errs = append(w.errs, fn)
+}
+
+// Start begins watching the files for changes.
+// An error is returned if the Watcher has already been started or has been closed.
+func (w *Watcher) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed() {
+		return errWatcherClosed
+	}
+	if w.closer != nil {
+		return errors.New("watcher already started")
+	}
+	closer, err := notify(w.paths, w.notify)
+	if err != nil {
+		closer = w.poll()
+	}
+	w.closer = closer
+	w.wg.Add(1)
+	go w.run()
+	return nil
+}
+
+var errWatcherClosed = errors.New("watcher closed")
+
+// closed reports whether Close has been called. w.mu must be held.
+func (w *Watcher) closed() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops watching the files.
+func (w *Watcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		w.mu.Lock()
+		close(w.done)
+		var closer = w.closer
+		w.mu.Unlock()
+		if closer != nil {
+			err = closer.Close()
+		}
+		w.wg.Wait()
+	})
+	return err
+}
+
+// notify records that one of the files may have changed.
+func (w *Watcher) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+	var debounce = w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	var timer = time.NewTimer(debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-w.done:
+			timer.Stop()
+			return
+		case <-w.changed:
+			timer.Reset(debounce)
+		case <-timer.C:
+			w.reload()
+		}
+	}
+}
+
+// Reload re-reads the files with Load and, if they parse and pass Validate, replaces the
+// current Configs with them and calls the Subscribe and change callbacks.
+// Otherwise, the current Configs are kept and the error is returned.
+//
+// Reloads are performed one at a time, and the callbacks for each reload run
+// one at a time, in the order the reloads happened. Callbacks may call Reload:
+// if another Reload is already running callbacks, as it is when Reload is
+// called from a callback, Reload returns once the Configs are replaced and
+// that Reload runs the new callbacks after the current ones.
+func (w *Watcher) Reload() error {
+	w.reloading.Lock()
+	var load = w.Load
+	if load == nil {
+		load = ReadFiles
+	}
+	cfgs, err := load(w.paths...)
+	if err == nil && w.Validate != nil {
+		err = w.Validate(cfgs)
+	}
+	if err != nil {
+		w.reloading.Unlock()
+		return err
+	}
+
+	var prev = w.store.Swap(cfgs)
+	w.mu.Lock()
+	w.pending = append(w.pending, reloaded{prev, cfgs})
+	var deliver = !w.delivering
+	w.delivering = true
+	w.mu.Unlock()
+	w.reloading.Unlock()
+
+	if deliver {
+		w.deliver()
+	}
+	return nil
+}
+
+// deliver runs the callbacks for pending reloads until there are none left.
+func (w *Watcher) deliver() {
+	for {
+		w.mu.Lock()
+		if len(w.pending) == 0 {
+			w.delivering = false
+			w.mu.Unlock()
+			return
+		}
+		var r = w.pending[0]
+		w.pending = w.pending[1:]
+		var subs, changes = w.subs, w.changes
+		w.mu.Unlock()
+
+		for _, fn := range subs {
+			fn(r.next)
+		}
+		for _, fn := range changes {
+			fn(r.prev, r.next)
+		}
+	}
+}
+
+// reload calls Reload and reports any error to the OnError callbacks.
+func (w *Watcher) reload() {
+	if err := w.Reload(); err != nil {
+		w.mu.Lock()
+		var errs = w.errs
+		w.mu.Unlock()
+		for _, fn := range errs {
+			fn(err)
+		}
+	}
+}
+
+// poller checks the watched files for changes at a regular interval.
+type poller struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+func (p *poller) Close() error {
+	p.once.Do(func() { close(p.stop) })
+	return nil
+}
+
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func (w *Watcher) states() []fileState {
+	var states = make([]fileState, len(w.paths))
+	for i, path := range w.paths {
+		if fi, err := os.Stat(path); err == nil {
+			states[i] = fileState{true, fi.Size(), fi.ModTime()}
+		}
+	}
+	return states
+}
+
+func (w *Watcher) poll() io.Closer {
+	var interval = w.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	var p = &poller{stop: make(chan struct{})}
+	var last = w.states()
+	go func() {
+		var ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				var states = w.states()
+				for i := range states {
+					if states[i] != last[i] {
+						w.notify()
+						break
+					}
+				}
+				last = states
+			}
+		}
+	}()
+	return p
+}
@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2018 John Pruitt
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// notify watches the directories containing the given files with inotify and
+// calls changed whenever one of the files is written, created, removed or renamed.
+// Directories are watched rather than the files themselves so that files
+// replaced by renaming, as many editors do, continue to be watched.
+func notify(paths []string, changed func()) (io.Closer, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	var names = make(map[int32]map[string]bool)
+	for _, path := range paths {
+		var dir, name = filepath.Split(path)
+		wd, err := syscall.InotifyAddWatch(fd, dir, inotifyMask)
+		if err != nil {
+			syscall.Close(fd)
+			return nil, os.NewSyscallError("inotify_add_watch", err)
+		}
+		if names[int32(wd)] == nil {
+			names[int32(wd)] = make(map[string]bool)
+		}
+		names[int32(wd)][name] = true
+	}
+
+	// the descriptor is non-blocking, so reads go through the runtime poller
+	// and closing the file unblocks the goroutine below
+	var f = os.NewFile(uintptr(fd), "inotify")
+	go func() {
+		var buf [64 * (syscall.SizeofInotifyEvent + syscall.NAME_MAX + 1)]byte
+		for {
+			n, err := f.Read(buf[:])
+			if err != nil {
+				return
+			}
+			for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+				var ev = (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+				var start = off + syscall.SizeofInotifyEvent
+				var end = start + int(ev.Len)
+				if end > n {
+					break
+				}
+				var name = string(buf[start:end])
+				for i := 0; i < len(name); i++ {
+					if name[i] == 0 {
+						name = name[:i]
+						break
+					}
+				}
+				if names[ev.Wd][name] {
+					changed()
+				}
+				off = end
+			}
+		}
+	}()
+	return f, nil
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	var dir = t.TempDir()
+	var a, b = filepath.Join(dir, "a.conf"), filepath.Join(dir, "b.conf")
+	writeFile(t, a, "x = 1\ny = 2\ndb:\nport = 5432\n")
+	writeFile(t, b, "y = 3\nlog:\nlevel = info\n")
+
+	cfgs, err := ReadFiles(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tests = []struct {
+		name, key, val string
+	}{
+		{"", "x", "1"},
+		{"", "y", "3"},
+		{"db", "port", "5432"},
+		{"log", "level", "info"},
+	}
+	for _, test := range tests {
+		t.Run(test.name+"."+test.key, func(t *testing.T) {
+			val, err := cfgs[test.name].String(test.key)
+			if err != nil || val != test.val {
+				t.Errorf("expected %#v but got %#v (%v)", test.val, val, err)
+			}
+		})
+	}
+
+	_, err = ReadFiles(a, filepath.Join(dir, "missing.conf"))
+	t.Run("missing", func(t *testing.T) {
+		if err == nil {
+			t.Error("expected an error but did not get one")
+		}
+	})
+}
+
+func TestWatcher(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	w.Debounce = 10 * time.Millisecond
+	w.PollInterval = 10 * time.Millisecond
+	defer w.Close()
+
+	var updates = make(chan map[string]*Config, 10)
+	var errs = make(chan error, 10)
+	w.Subscribe(func(cfgs map[string]*Config) { updates <- cfgs })
+	w.OnError(func(err error) { errs <- err })
+	if err := w.Start(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	writeFile(t, path, "level = debug\n")
+	select {
+	case cfgs := <-updates:
+		if level, _ := cfgs[""].String("level"); level != "debug" {
+			t.Errorf("expected 'debug' but got '%s'", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	writeFile(t, path, "this is not valid\n")
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+	if level, _ := w.Configs()[""].String("level"); level != "debug" {
+		t.Errorf("expected last good value 'debug' but got '%s'", level)
+	}
+}
+
+func TestWatcher_poll(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	w.PollInterval = 10 * time.Millisecond
+	var p = w.poll()
+	defer p.Close()
+
+	// make sure the modification time differs on coarse-grained file systems
+	var later = time.Now().Add(time.Minute)
+	writeFile(t, path, "level = debug\n")
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	select {
+	case <-w.changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+	}
+}
+
+func TestNewWatcherFunc(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "n = 10\n")
+
+	w, err := NewWatcherFunc(func(paths ...string) (map[string]*Config, error) {
+		cfgs, err := ReadFiles(paths...)
+		if err == nil {
+			cfgs[""].SetDecimalIntegers(true)
+		}
+		return cfgs, err
+	}, path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n, err := w.Configs()[""].Int("n"); err != nil || n != 10 {
+		t.Errorf("expected 10 but got %d (%v)", n, err)
+	}
+
+	writeFile(t, path, "n = 0x10\n")
+	if err := w.Reload(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n, err := w.Configs()[""].Int("n"); err == nil {
+		t.Errorf("expected the settings to be applied after a reload, got %d", n)
+	}
+}
+
+func TestWatcher_StartClose(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var started = make(chan error, 1)
+	go func() { started <- w.Start() }()
+	w.Close()
+	<-started
+	if err := w.Start(); err == nil {
+		t.Error("expected an error starting a closed watcher")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("unexpected error closing twice: %s", err)
+	}
+}
+
+func TestWatcher_ReloadFromCallback(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "app.conf")
+	writeFile(t, path, "level = info\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var levels []string
+	w.Subscribe(func(cfgs map[string]*Config) {
+		level, _ := cfgs[""].String("level")
+		levels = append(levels, level)
+		if level == "debug" {
+			writeFile(t, path, "level = trace\n")
+			if err := w.Reload(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			levels = append(levels, "reloaded")
+		}
+	})
+
+	var done = make(chan struct{})
+	go func() {
+		defer close(done)
+		writeFile(t, path, "level = debug\n")
+		w.Reload()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out: Reload from a callback deadlocked")
+	}
+	var exp = []string{"debug", "reloaded", "trace"}
+	if fmt.Sprint(levels) != fmt.Sprint(exp) {
+		t.Errorf("expected %v but got %v", exp, levels)
+	}
+}